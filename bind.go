@@ -0,0 +1,140 @@
+package form_validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// fieldBinding links a struct field to the form field it is bound from.
+type fieldBinding struct {
+	name        string
+	value       reflect.Value
+	validations []ValidationFunc
+}
+
+// Bind validates the form values and assigns them to the fields of the struct
+// pointed to by dst. Struct fields are bound through tags such as
+//
+//	Email string `form:"email" validate:"required,email"`
+//	Age   int    `form:"age" validate:"required,intrange:18:120"`
+//
+// where validate holds comma-separated rules, each in the syntax accepted by
+// ParseRules. Supported field kinds are strings, integers, floats and booleans.
+// Empty numeric fields keep their zero value unless a rule such as "required"
+// rejects them. Boolean fields also accept the checkbox values "on" and "yes",
+// and are false when empty. Validation failures are recorded on v, while the
+// returned error reports an invalid destination or rule.
+func (v *Validator) Bind(dst interface{}) error {
+	ptr := reflect.ValueOf(dst)
+	if ptr.Kind() != reflect.Pointer || ptr.IsNil() || ptr.Elem().Kind() != reflect.Struct {
+		return errors.New("form_validator: Bind expects a non-nil pointer to a struct")
+	}
+
+	bindings, err := structBindings(ptr.Elem())
+	if err != nil {
+		return err
+	}
+
+	for _, b := range bindings {
+		kind := b.value.Kind()
+		if kind != reflect.String && kind != reflect.Bool && v.GetValue(b.name) == "" {
+			// Optional numeric fields are left at their zero value.
+			v.validate(b.name, "", b.validations)
+			continue
+		}
+
+		switch kind {
+		case reflect.String:
+			b.value.SetString(v.String(b.name, b.validations...))
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			intValue, ok := v.IntOK(b.name, b.validations...)
+			if ok && b.value.OverflowInt(intValue) {
+				v.addError(b.name, "This field is out of range")
+				continue
+			}
+			b.value.SetInt(intValue)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			intValue, ok := v.IntOK(b.name, b.validations...)
+			if ok && (intValue < 0 || b.value.OverflowUint(uint64(intValue))) {
+				v.addError(b.name, "This field is out of range")
+				continue
+			}
+			b.value.SetUint(uint64(intValue))
+		case reflect.Float32, reflect.Float64:
+			floatValue := v.Float(b.name, b.validations...)
+			if b.value.OverflowFloat(floatValue) {
+				v.addError(b.name, "This field is out of range")
+				continue
+			}
+			b.value.SetFloat(floatValue)
+		case reflect.Bool:
+			value := strings.TrimSpace(strings.ToLower(v.String(b.name, b.validations...)))
+			switch value {
+			case "":
+				b.value.SetBool(false)
+				continue
+			case "on", "yes":
+				b.value.SetBool(true)
+				continue
+			}
+
+			boolValue, err := strconv.ParseBool(value)
+			if err != nil {
+				v.addError(b.name, "This field must be true or false")
+				continue
+			}
+			b.value.SetBool(boolValue)
+		}
+	}
+
+	return nil
+}
+
+// structBindings collects the tagged fields of a struct and parses their rules, so
+// that an invalid tag is reported before any value is validated.
+func structBindings(s reflect.Value) ([]fieldBinding, error) {
+	bindings := make([]fieldBinding, 0, s.NumField())
+
+	for i := 0; i < s.NumField(); i++ {
+		field := s.Type().Field(i)
+
+		name := field.Tag.Get("form")
+		if name == "" || name == "-" || !field.IsExported() {
+			continue
+		}
+
+		switch field.Type.Kind() {
+		case reflect.String, reflect.Bool, reflect.Float32, reflect.Float64,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		default:
+			return nil, fmt.Errorf("form_validator: field %s has unsupported type %s", field.Name, field.Type)
+		}
+
+		validations := make([]ValidationFunc, 0)
+		for _, spec := range strings.Split(field.Tag.Get("validate"), ",") {
+			spec = strings.TrimSpace(spec)
+			if spec == "" {
+				continue
+			}
+
+			validation, err := parseRule(spec)
+			if err != nil {
+				return nil, fmt.Errorf("form_validator: field %s: %w", field.Name, err)
+			}
+
+			validations = append(validations, validation)
+		}
+
+		bindings = append(bindings, fieldBinding{
+			name:        name,
+			value:       s.Field(i),
+			validations: validations,
+		})
+	}
+
+	return bindings, nil
+}
@@ -0,0 +1,126 @@
+package form_validator
+
+import "testing"
+
+type signupForm struct {
+	Email      string  `form:"email" validate:"required,email"`
+	Age        int     `form:"age" validate:"required,intrange:18:120"`
+	Score      float64 `form:"score"`
+	Newsletter bool    `form:"newsletter"`
+	Level      uint8   `form:"level"`
+	Ignored    string
+}
+
+func TestValidator_Bind(t *testing.T) {
+	v := New()
+	v.SetValue("email", "john@example.com")
+	v.SetValue("age", "42")
+	v.SetValue("score", "9.5")
+	v.SetValue("newsletter", "on")
+	v.SetValue("level", "3")
+	v.SetValue("Ignored", "value")
+
+	var form signupForm
+	if err := v.Bind(&form); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := signupForm{Email: "john@example.com", Age: 42, Score: 9.5, Newsletter: true, Level: 3}
+	if form != want {
+		t.Errorf("Bind() = %+v, want %+v", form, want)
+	}
+	if !v.Valid() {
+		t.Errorf("Expected no errors, got %v", v.Errors)
+	}
+}
+
+func TestValidator_BindEmptyNumbers(t *testing.T) {
+	v := New()
+	v.SetValue("email", "john@example.com")
+
+	var form signupForm
+	if err := v.Bind(&form); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if form.Score != 0 || form.Level != 0 {
+		t.Errorf("Expected optional numbers to keep their zero value, got %+v", form)
+	}
+	if len(v.Errors) != 1 || v.Errors["age"] != "This field is required" {
+		t.Errorf("Expected only the required age field to fail, got %v", v.Errors)
+	}
+}
+
+func TestValidator_BindInvalidValues(t *testing.T) {
+	tests := []struct {
+		name  string
+		field string
+		value string
+	}{
+		{"invalid email", "email", "john"},
+		{"age below range", "age", "17"},
+		{"age not a number", "age", "old"},
+		{"invalid score", "score", "high"},
+		{"invalid boolean", "newsletter", "maybe"},
+		{"level overflows uint8", "level", "300"},
+		{"negative level", "level", "-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New()
+			v.SetValue("email", "john@example.com")
+			v.SetValue("age", "42")
+			v.SetValue("score", "1")
+			v.SetValue("level", "1")
+			v.SetValue(tt.field, tt.value)
+
+			var form signupForm
+			if err := v.Bind(&form); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if _, ok := v.Errors[tt.field]; !ok || len(v.Errors) != 1 {
+				t.Errorf("Expected a single error on %s, got %v", tt.field, v.Errors)
+			}
+		})
+	}
+}
+
+func TestValidator_BindErrors(t *testing.T) {
+	var unknownRule struct {
+		Name string `form:"name" validate:"required,nope"`
+	}
+	var badArgument struct {
+		Age int `form:"age" validate:"intrange:18"`
+	}
+	var unsupportedType struct {
+		Tags []string `form:"tags"`
+	}
+	var form signupForm
+
+	tests := []struct {
+		name string
+		dst  interface{}
+	}{
+		{"unknown rule", &unknownRule},
+		{"invalid rule argument", &badArgument},
+		{"unsupported field type", &unsupportedType},
+		{"not a pointer", form},
+		{"nil pointer", (*signupForm)(nil)},
+		{"pointer to non-struct", new(string)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New()
+			v.SetValue("name", "John")
+			if err := v.Bind(tt.dst); err == nil {
+				t.Error("Expected error")
+			}
+			if !v.Valid() {
+				t.Errorf("Expected no validation to run, got %v", v.Errors)
+			}
+		})
+	}
+}
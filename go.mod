@@ -1,3 +1,10 @@
 module github.com/arkan/form_validator
 
 go 1.23.4
+
+require (
+	golang.org/x/crypto v0.41.0
+	golang.org/x/text v0.28.0
+)
+
+require golang.org/x/sys v0.35.0 // indirect
@@ -0,0 +1,320 @@
+package form_validator
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"mime/multipart"
+)
+
+// EXIF tags used when inspecting JPEG metadata.
+const (
+	exifTagOrientation = 0x0112
+	exifTagGPSInfo     = 0x8825
+)
+
+// Trailers marking the end of complete JPEG and PNG files.
+var (
+	jpegTrailer = []byte{0xFF, 0xD9}
+	pngTrailer  = []byte{0x00, 0x00, 0x00, 0x00, 'I', 'E', 'N', 'D', 0xAE, 0x42, 0x60, 0x82}
+)
+
+// exifMetadata holds the EXIF fields relevant to validation.
+type exifMetadata struct {
+	orientation int
+	hasGPS      bool
+}
+
+// maxProcessedPixels bounds the dimensions of the images ProcessedImage decodes,
+// since a small compressed file can claim dimensions needing gigabytes of memory.
+const maxProcessedPixels = 40_000_000
+
+// ProcessedImage returns the uploaded image re-encoded without any metadata.
+// For JPEG images, the EXIF orientation is applied to the pixels before the
+// metadata is dropped so the image keeps displaying the right way up. Images
+// larger than 40 megapixels are rejected without being decoded. When the field
+// was validated with FileValidationConfig.StripMetadata, the image processed
+// during validation is returned.
+func (v *Validator) ProcessedImage(field string) ([]byte, error) {
+	if processed, ok := v.processedImages[field]; ok {
+		return processed, nil
+	}
+
+	file := v.GetFile(field)
+	if file == nil {
+		return nil, errors.New("no file was uploaded")
+	}
+
+	return processImage(file, file.Size)
+}
+
+// processImage decodes an uploaded image of at most maxSize bytes and re-encodes
+// it without metadata.
+func processImage(file *multipart.FileHeader, maxSize int64) ([]byte, error) {
+	f, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	content, err := io.ReadAll(io.LimitReader(f, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > maxSize {
+		return nil, errors.New("image exceeds the maximum size")
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	if int64(config.Width)*int64(config.Height) > maxProcessedPixels {
+		return nil, errors.New("image dimensions exceed the maximum limit")
+	}
+
+	img, format, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	switch format {
+	case "jpeg":
+		metadata, err := readEXIF(bytes.NewReader(content))
+		if err != nil {
+			return nil, err
+		}
+
+		err = jpeg.Encode(&buffer, applyOrientation(img, metadata.orientation), &jpeg.Options{Quality: 90})
+		if err != nil {
+			return nil, err
+		}
+	case "png":
+		if err := png.Encode(&buffer, img); err != nil {
+			return nil, err
+		}
+	case "gif":
+		if err := gif.Encode(&buffer, img, nil); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New("unsupported image format: " + format)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// ImagesSameDimensions validates that the images uploaded in fieldA and fieldB
+// have the same width and height. Errors are recorded on fieldB.
+func (v *Validator) ImagesSameDimensions(fieldA, fieldB string) {
+	configA, ok := v.imageConfig(fieldA)
+	if !ok {
+		return
+	}
+
+	configB, ok := v.imageConfig(fieldB)
+	if !ok {
+		return
+	}
+
+	if configA.Width != configB.Width || configA.Height != configB.Height {
+		v.addError(fieldB, "Images must have the same dimensions")
+	}
+}
+
+// imageConfig decodes the dimensions of an uploaded image, recording an error on failure.
+func (v *Validator) imageConfig(field string) (image.Config, bool) {
+	file := v.GetFile(field)
+	if file == nil {
+		v.addError(field, "No file was uploaded")
+		return image.Config{}, false
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		v.addError(field, "Could not process file")
+		return image.Config{}, false
+	}
+	defer f.Close()
+
+	config, _, err := image.DecodeConfig(f)
+	if err != nil {
+		v.addError(field, "Could not read image dimensions")
+		return image.Config{}, false
+	}
+
+	return config, true
+}
+
+// hasValidTrailer reports whether a file of the given detected type ends with the
+// trailer of its format. Types without a known trailer are always accepted.
+func hasValidTrailer(r io.ReaderAt, size int64, detectedType string) bool {
+	var trailer []byte
+	switch detectedType {
+	case MimeJPEG:
+		trailer = jpegTrailer
+	case MimePNG:
+		trailer = pngTrailer
+	default:
+		return true
+	}
+
+	if size < int64(len(trailer)) {
+		return false
+	}
+
+	tail := make([]byte, len(trailer))
+	if _, err := r.ReadAt(tail, size-int64(len(tail))); err != nil {
+		return false
+	}
+
+	return bytes.Equal(tail, trailer)
+}
+
+// hasPlausibleSize reports whether an image of size bytes has at least
+// minBytesPerPixel bytes per pixel. Images in formats that cannot be decoded are
+// accepted, as their dimensions are unknown.
+func hasPlausibleSize(r io.Reader, size int64, minBytesPerPixel float64) bool {
+	config, _, err := image.DecodeConfig(r)
+	if errors.Is(err, image.ErrFormat) {
+		return true
+	}
+	if err != nil {
+		return false
+	}
+
+	pixels := float64(config.Width) * float64(config.Height)
+	if pixels == 0 {
+		return false
+	}
+
+	return float64(size)/pixels >= minBytesPerPixel
+}
+
+// readEXIF reads the EXIF metadata from a JPEG stream. Images without EXIF
+// metadata are reported with the default orientation.
+func readEXIF(r io.Reader) (exifMetadata, error) {
+	metadata := exifMetadata{orientation: 1}
+	br := bufio.NewReader(r)
+
+	var soi [2]byte
+	if _, err := io.ReadFull(br, soi[:]); err != nil || soi != [2]byte{0xFF, 0xD8} {
+		return metadata, errors.New("not a JPEG image")
+	}
+
+	for {
+		var marker [4]byte
+		if _, err := io.ReadFull(br, marker[:]); err != nil {
+			return metadata, err
+		}
+		if marker[0] != 0xFF {
+			return metadata, errors.New("invalid JPEG segment")
+		}
+
+		// Metadata segments always precede the image data.
+		if marker[1] == 0xDA || marker[1] == 0xD9 {
+			return metadata, nil
+		}
+
+		length := int(binary.BigEndian.Uint16(marker[2:]))
+		if length < 2 {
+			return metadata, errors.New("invalid JPEG segment length")
+		}
+
+		segment := make([]byte, length-2)
+		if _, err := io.ReadFull(br, segment); err != nil {
+			return metadata, err
+		}
+
+		if marker[1] == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return parseTIFF(segment[6:], metadata)
+		}
+	}
+}
+
+// parseTIFF reads the first IFD of an EXIF TIFF structure.
+func parseTIFF(data []byte, metadata exifMetadata) (exifMetadata, error) {
+	if len(data) < 8 {
+		return metadata, errors.New("invalid EXIF header")
+	}
+
+	var order binary.ByteOrder
+	switch string(data[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return metadata, errors.New("invalid EXIF byte order")
+	}
+
+	offset := int(order.Uint32(data[4:]))
+	if offset+2 > len(data) {
+		return metadata, errors.New("invalid EXIF offset")
+	}
+
+	count := int(order.Uint16(data[offset:]))
+	for i := 0; i < count; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > len(data) {
+			return metadata, errors.New("truncated EXIF entry")
+		}
+
+		switch order.Uint16(data[entry:]) {
+		case exifTagOrientation:
+			metadata.orientation = int(order.Uint16(data[entry+8:]))
+		case exifTagGPSInfo:
+			metadata.hasGPS = true
+		}
+	}
+
+	return metadata, nil
+}
+
+// applyOrientation transforms an image according to an EXIF orientation value.
+func applyOrientation(img image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+
+	dstW, dstH := w, h
+	if orientation >= 5 {
+		dstW, dstH = h, w
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dstW, dstH))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2:
+				dx, dy = w-1-x, y
+			case 3:
+				dx, dy = w-1-x, h-1-y
+			case 4:
+				dx, dy = x, h-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = h-1-y, x
+			case 7:
+				dx, dy = h-1-y, w-1-x
+			case 8:
+				dx, dy = y, w-1-x
+			}
+			dst.Set(dx, dy, img.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,303 @@
+package form_validator
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"mime/multipart"
+	"testing"
+)
+
+// createJPEG encodes a w x h JPEG and optionally injects an EXIF segment with
+// the given orientation and a GPS pointer.
+func createJPEG(t *testing.T, w, h, orientation int, gps bool) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	img.Set(0, 0, color.White)
+
+	var buffer bytes.Buffer
+	if err := jpeg.Encode(&buffer, img, nil); err != nil {
+		t.Fatal(err)
+	}
+	encoded := buffer.Bytes()
+
+	if orientation == 0 && !gps {
+		return encoded
+	}
+
+	// Build a little-endian TIFF structure with a single IFD.
+	var entries [][]byte
+	if orientation != 0 {
+		entry := make([]byte, 12)
+		binary.LittleEndian.PutUint16(entry[0:], exifTagOrientation)
+		binary.LittleEndian.PutUint16(entry[2:], 3)
+		binary.LittleEndian.PutUint32(entry[4:], 1)
+		binary.LittleEndian.PutUint16(entry[8:], uint16(orientation))
+		entries = append(entries, entry)
+	}
+	if gps {
+		entry := make([]byte, 12)
+		binary.LittleEndian.PutUint16(entry[0:], exifTagGPSInfo)
+		binary.LittleEndian.PutUint16(entry[2:], 4)
+		binary.LittleEndian.PutUint32(entry[4:], 1)
+		entries = append(entries, entry)
+	}
+
+	tiff := []byte{'I', 'I', 0x2A, 0x00, 0x08, 0x00, 0x00, 0x00}
+	tiff = binary.LittleEndian.AppendUint16(tiff, uint16(len(entries)))
+	for _, entry := range entries {
+		tiff = append(tiff, entry...)
+	}
+	tiff = append(tiff, 0, 0, 0, 0)
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xFF, 0xE1}
+	segment = binary.BigEndian.AppendUint16(segment, uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	result := append([]byte{}, encoded[:2]...)
+	result = append(result, segment...)
+	return append(result, encoded[2:]...)
+}
+
+func TestValidator_ImageRejectGPS(t *testing.T) {
+	config := ImageConfig(1 * MB)
+	config.RejectGPS = true
+
+	tests := []struct {
+		name    string
+		content []byte
+		wantErr bool
+	}{
+		{"no metadata", createJPEG(t, 2, 2, 0, false), false},
+		{"orientation only", createJPEG(t, 2, 2, 6, false), false},
+		{"gps metadata", createJPEG(t, 2, 2, 1, true), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New()
+			v.SetFile("photo", createFileHeader(t, "photo", "photo.jpg", tt.content))
+			v.Image("photo", config)
+
+			if _, ok := v.Errors["photo"]; ok != tt.wantErr {
+				t.Errorf("error present = %v, want %v (errors: %v)", ok, tt.wantErr, v.Errors)
+			}
+		})
+	}
+}
+
+func TestValidator_ImageDeepScan(t *testing.T) {
+	config := ImageConfig(1 * MB)
+	config.DeepScan = true
+
+	jpegContent := createJPEG(t, 4, 4, 0, false)
+
+	var pngBuffer bytes.Buffer
+	if err := png.Encode(&pngBuffer, image.NewRGBA(image.Rect(0, 0, 4, 4))); err != nil {
+		t.Fatal(err)
+	}
+	pngContent := pngBuffer.Bytes()
+
+	tests := []struct {
+		name     string
+		filename string
+		content  []byte
+		wantErr  bool
+	}{
+		{"complete jpeg", "photo.jpg", jpegContent, false},
+		{"truncated jpeg", "photo.jpg", jpegContent[:len(jpegContent)-10], true},
+		{"complete png", "photo.png", pngContent, false},
+		{"truncated png", "photo.png", pngContent[:len(pngContent)-4], true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New()
+			v.SetFile("photo", createFileHeader(t, "photo", tt.filename, tt.content))
+			v.Image("photo", config)
+
+			if _, ok := v.Errors["photo"]; ok != tt.wantErr {
+				t.Errorf("error present = %v, want %v (errors: %v)", ok, tt.wantErr, v.Errors)
+			}
+		})
+	}
+}
+
+func TestValidator_ImageMinBytesPerPixel(t *testing.T) {
+	config := ImageConfig(1 * MB)
+	config.MinBytesPerPixel = 0.01
+
+	// A blank 2000x2000 PNG compresses to a few kilobytes.
+	var bomb bytes.Buffer
+	if err := png.Encode(&bomb, image.NewGray(image.Rect(0, 0, 2000, 2000))); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		filename string
+		content  []byte
+		wantErr  bool
+	}{
+		{"regular image", "photo.jpg", createJPEG(t, 4, 4, 0, false), false},
+		{"over-compressed image", "photo.png", bomb.Bytes(), true},
+		{"unknown format", "photo.webp", []byte("RIFF\x00\x00\x00\x00WEBPVP8 "), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New()
+			v.SetFile("photo", createFileHeader(t, "photo", tt.filename, tt.content))
+			v.Image("photo", config)
+
+			if _, ok := v.Errors["photo"]; ok != tt.wantErr {
+				t.Errorf("error present = %v, want %v (errors: %v)", ok, tt.wantErr, v.Errors)
+			}
+		})
+	}
+}
+
+func TestValidator_Images(t *testing.T) {
+	v := New()
+	v.SetFiles("photos", []*multipart.FileHeader{
+		createFileHeader(t, "photos", "first.jpg", createJPEG(t, 2, 2, 0, false)),
+		createFileHeader(t, "photos", "notes.txt", []byte("not an image")),
+		createFileHeader(t, "photos", "third.jpg", createJPEG(t, 2, 2, 0, false)),
+	})
+
+	got := v.Images("photos", ImageConfig(1*MB))
+
+	if len(got) != 2 || got[0].Filename != "first.jpg" || got[1].Filename != "third.jpg" {
+		t.Errorf("Images() returned %d files, want first.jpg and third.jpg", len(got))
+	}
+	if _, ok := v.Errors["photos[1]"]; !ok {
+		t.Errorf("Expected error for photos[1], got %v", v.Errors)
+	}
+	if len(v.Errors) != 1 {
+		t.Errorf("Expected exactly one error, got %v", v.Errors)
+	}
+
+	v = New()
+	v.Images("photos", ImageConfig(1*MB))
+	if _, ok := v.Errors["photos"]; !ok {
+		t.Error("Expected error when no file was uploaded")
+	}
+}
+
+func TestValidator_ProcessedImage(t *testing.T) {
+	v := New()
+	v.SetFile("photo", createFileHeader(t, "photo", "photo.jpg", createJPEG(t, 4, 2, 6, true)))
+
+	processed, err := v.ProcessedImage("photo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	metadata, err := readEXIF(bytes.NewReader(processed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if metadata.hasGPS || metadata.orientation != 1 {
+		t.Errorf("Expected metadata to be stripped, got %+v", metadata)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(processed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Width != 2 || config.Height != 4 {
+		t.Errorf("Expected rotated 2x4 image, got %dx%d", config.Width, config.Height)
+	}
+
+	if _, err := New().ProcessedImage("photo"); err == nil {
+		t.Error("Expected error when no file was uploaded")
+	}
+}
+
+func TestValidator_ProcessedImageLimits(t *testing.T) {
+	// A PNG header claiming 50000x50000 pixels, without any image data.
+	header := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR")
+	header = binary.BigEndian.AppendUint32(header, 50000)
+	header = binary.BigEndian.AppendUint32(header, 50000)
+	header = append(header, 8, 6, 0, 0, 0, 0, 0, 0, 0)
+
+	v := New()
+	v.SetFile("photo", createFileHeader(t, "photo", "photo.png", header))
+	if _, err := v.ProcessedImage("photo"); err == nil {
+		t.Error("Expected error for an image exceeding the pixel limit")
+	}
+
+	if _, err := processImage(createFileHeader(t, "photo", "photo.jpg", createJPEG(t, 4, 4, 0, false)), 10); err == nil {
+		t.Error("Expected error for an image exceeding the maximum size")
+	}
+}
+
+func TestValidator_ImageStripMetadata(t *testing.T) {
+	config := ImageConfig(1 * MB)
+	config.StripMetadata = true
+
+	v := New()
+	v.SetFile("photo", createFileHeader(t, "photo", "photo.jpg", createJPEG(t, 4, 2, 6, true)))
+	if v.Image("photo", config) == nil {
+		t.Fatalf("Image() returned nil (errors: %v)", v.Errors)
+	}
+
+	processed, err := v.ProcessedImage("photo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	metadata, err := readEXIF(bytes.NewReader(processed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if metadata.hasGPS || metadata.orientation != 1 {
+		t.Errorf("Expected metadata to be stripped, got %+v", metadata)
+	}
+
+	v = New()
+	v.SetFile("photo", createFileHeader(t, "photo", "photo.jpg", createJPEG(t, 4, 2, 0, false)[:200]))
+	if v.Image("photo", config) != nil {
+		t.Error("Expected a corrupt image to be rejected")
+	}
+	if _, ok := v.Errors["photo"]; !ok {
+		t.Error("Expected error for field photo")
+	}
+}
+
+func TestValidator_ImagesSameDimensions(t *testing.T) {
+	tests := []struct {
+		name    string
+		before  []byte
+		after   []byte
+		wantErr bool
+	}{
+		{"same dimensions", createJPEG(t, 4, 3, 0, false), createJPEG(t, 4, 3, 0, false), false},
+		{"different dimensions", createJPEG(t, 4, 3, 0, false), createJPEG(t, 3, 4, 0, false), true},
+		{"not an image", createJPEG(t, 4, 3, 0, false), []byte("text"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New()
+			v.SetFile("before", createFileHeader(t, "before", "before.jpg", tt.before))
+			v.SetFile("after", createFileHeader(t, "after", "after.jpg", tt.after))
+			v.ImagesSameDimensions("before", "after")
+
+			if _, ok := v.Errors["after"]; ok != tt.wantErr {
+				t.Errorf("error present = %v, want %v (errors: %v)", ok, tt.wantErr, v.Errors)
+			}
+		})
+	}
+
+	v := New()
+	v.SetFile("before", createFileHeader(t, "before", "before.jpg", createJPEG(t, 4, 3, 0, false)))
+	v.ImagesSameDimensions("before", "after")
+	if _, ok := v.Errors["after"]; !ok {
+		t.Error("Expected error when an image is missing")
+	}
+}
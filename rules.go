@@ -0,0 +1,265 @@
+package form_validator
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Rules maps field names to pipe-separated rule strings such as "required|email".
+type Rules map[string]string
+
+// ruleFactory builds a validation function from the arguments of a rule.
+type ruleFactory func(args []string) (ValidationFunc, error)
+
+// builtinRules maps rule names to their factories.
+var builtinRules = map[string]ruleFactory{
+	"required":     noArgs(Required),
+	"email":        noArgs(Email),
+	"boolean":      noArgs(Boolean),
+	"int":          noArgs(integer),
+	"even":         noArgs(Even),
+	"odd":          noArgs(Odd),
+	"language_tag": noArgs(LanguageTag),
+	"query_string": noArgs(QueryString),
+	"min":          intArg(MinLength),
+	"max":          intArg(MaxLength),
+	"intrange":     intRangeArgs(IntRange),
+}
+
+// customRules holds the rules registered by the application.
+var (
+	customRulesMu sync.RWMutex
+	customRules   = map[string]ruleFactory{}
+)
+
+// RegisterRule registers a custom rule so it can be used by ParseRules.
+// It panics if a built-in or previously registered rule has the same name;
+// use OverrideRule to replace an existing rule on purpose.
+func RegisterRule(name string, factory func(args []string) (ValidationFunc, error)) {
+	customRulesMu.Lock()
+	defer customRulesMu.Unlock()
+
+	if factory == nil {
+		panic("form_validator: RegisterRule factory is nil")
+	}
+
+	_, builtin := builtinRules[name]
+	_, registered := customRules[name]
+	if builtin || registered {
+		panic(fmt.Sprintf("form_validator: rule %q is already registered", name))
+	}
+
+	customRules[name] = factory
+}
+
+// OverrideRule registers a rule, replacing any built-in or custom rule with the same name.
+func OverrideRule(name string, factory func(args []string) (ValidationFunc, error)) {
+	customRulesMu.Lock()
+	defer customRulesMu.Unlock()
+
+	if factory == nil {
+		panic("form_validator: OverrideRule factory is nil")
+	}
+
+	customRules[name] = factory
+}
+
+// ParseRules parses a pipe-separated rule string such as "required|min:3|max:20"
+// into validation functions. Rule arguments are separated by colons.
+func ParseRules(rules string) ([]ValidationFunc, error) {
+	validations := make([]ValidationFunc, 0)
+
+	for _, spec := range strings.Split(rules, "|") {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+
+		validation, err := parseRule(spec)
+		if err != nil {
+			return nil, err
+		}
+
+		validations = append(validations, validation)
+	}
+
+	return validations, nil
+}
+
+// BindDynamic validates fields against pipe-separated rule strings and returns the
+// values typed by rule: fields with an "int" rule become int64, fields with a
+// "boolean" rule become bool, and everything else stays a string. As with Bind,
+// all rules are parsed first and an invalid rule is returned as an error without
+// validating any field.
+func (v *Validator) BindDynamic(rules map[string]string) (map[string]any, error) {
+	parsed, err := parseRuleSet(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]any, len(rules))
+
+	for field, validations := range parsed {
+		names := ruleNames(rules[field])
+		switch {
+		case names["int"]:
+			result[field] = v.Int(field, validations...)
+		case names["boolean"]:
+			value, _ := strconv.ParseBool(strings.TrimSpace(strings.ToLower(v.String(field, validations...))))
+			result[field] = value
+		default:
+			result[field] = v.String(field, validations...)
+		}
+	}
+
+	return result, nil
+}
+
+// parseRuleSet parses the rule string of every field. Fields are parsed in sorted
+// order so that the same invalid rule set always reports the same error.
+func parseRuleSet(rules map[string]string) (map[string][]ValidationFunc, error) {
+	fields := make([]string, 0, len(rules))
+	for field := range rules {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	parsed := make(map[string][]ValidationFunc, len(rules))
+	for _, field := range fields {
+		validations, err := ParseRules(rules[field])
+		if err != nil {
+			return nil, fmt.Errorf("form_validator: field %s: %w", field, err)
+		}
+		parsed[field] = validations
+	}
+
+	return parsed, nil
+}
+
+// ValidateRows validates each row (e.g. a record of a CSV import) against the same
+// rules and returns one validator per row, in order. Rules are parsed once, before
+// any row is validated; an invalid rule is returned as an error.
+func ValidateRows(rows []map[string]string, rules Rules) ([]*Validator, error) {
+	validations, err := parseRuleSet(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	validators := make([]*Validator, len(rows))
+	for i, row := range rows {
+		v := New()
+		for field, value := range row {
+			v.SetValue(field, value)
+		}
+
+		for field, fieldValidations := range validations {
+			v.String(field, fieldValidations...)
+		}
+
+		validators[i] = v
+	}
+
+	return validators, nil
+}
+
+// parseRule parses a single rule such as "min:3".
+func parseRule(spec string) (ValidationFunc, error) {
+	parts := strings.Split(spec, ":")
+	name, args := parts[0], parts[1:]
+
+	factory, ok := lookupRule(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown validation rule %q", name)
+	}
+
+	validation, err := factory(args)
+	if err != nil {
+		return nil, fmt.Errorf("validation rule %q: %w", name, err)
+	}
+
+	return validation, nil
+}
+
+// lookupRule finds a rule factory by name, custom rules taking precedence.
+func lookupRule(name string) (ruleFactory, bool) {
+	customRulesMu.RLock()
+	factory, ok := customRules[name]
+	customRulesMu.RUnlock()
+	if ok {
+		return factory, true
+	}
+
+	factory, ok = builtinRules[name]
+	return factory, ok
+}
+
+// ruleNames returns the set of rule names used in a pipe-separated rule string.
+func ruleNames(rules string) map[string]bool {
+	names := make(map[string]bool)
+	for _, spec := range strings.Split(rules, "|") {
+		name, _, _ := strings.Cut(strings.TrimSpace(spec), ":")
+		names[name] = true
+	}
+
+	return names
+}
+
+// noArgs adapts a validation function into a rule that takes no arguments.
+func noArgs(validation ValidationFunc) ruleFactory {
+	return func(args []string) (ValidationFunc, error) {
+		if len(args) != 0 {
+			return nil, fmt.Errorf("takes no arguments, got %d", len(args))
+		}
+
+		return validation, nil
+	}
+}
+
+// intArg adapts a validation function constructor into a rule taking one integer argument.
+func intArg(constructor func(int) ValidationFunc) ruleFactory {
+	return func(args []string) (ValidationFunc, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("expects 1 argument, got %d", len(args))
+		}
+
+		n, err := strconv.Atoi(args[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer argument %q", args[0])
+		}
+
+		return constructor(n), nil
+	}
+}
+
+// intRangeArgs adapts a validation function constructor into a rule taking a
+// minimum and a maximum integer argument, e.g. "intrange:18:120".
+func intRangeArgs(constructor func(min, max int) ValidationFunc) ruleFactory {
+	return func(args []string) (ValidationFunc, error) {
+		if len(args) != 2 {
+			return nil, fmt.Errorf("expects 2 arguments, got %d", len(args))
+		}
+
+		bounds := make([]int, len(args))
+		for i, arg := range args {
+			n, err := strconv.Atoi(arg)
+			if err != nil {
+				return nil, fmt.Errorf("invalid integer argument %q", arg)
+			}
+			bounds[i] = n
+		}
+
+		return constructor(bounds[0], bounds[1]), nil
+	}
+}
+
+// integer validates that a value is an integer.
+func integer(field, value string) (bool, string) {
+	if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+		return false, "This field must be a valid integer"
+	}
+
+	return true, ""
+}
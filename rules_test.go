@@ -0,0 +1,200 @@
+package form_validator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   string
+		value   string
+		wantErr bool
+		wantOK  bool
+	}{
+		{name: "valid value", rules: "required|min:3|max:5", value: "john", wantOK: true},
+		{name: "too short", rules: "required|min:3", value: "jo", wantOK: false},
+		{name: "empty rules", rules: "", value: "", wantOK: true},
+		{name: "unknown rule", rules: "required|nope", wantErr: true},
+		{name: "missing argument", rules: "min", wantErr: true},
+		{name: "invalid argument", rules: "min:abc", wantErr: true},
+		{name: "unexpected argument", rules: "required:1", wantErr: true},
+		{name: "int in range", rules: "intrange:1:10", value: "10", wantOK: true},
+		{name: "int out of range", rules: "intrange:1:10", value: "11", wantOK: false},
+		{name: "missing range bound", rules: "intrange:1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validations, err := ParseRules(tt.rules)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			v := New()
+			v.SetValue("field", tt.value)
+			v.String("field", validations...)
+			if v.Valid() != tt.wantOK {
+				t.Errorf("Valid() = %v, want %v (errors: %v)", v.Valid(), tt.wantOK, v.Errors)
+			}
+		})
+	}
+}
+
+func TestValidator_BindDynamic(t *testing.T) {
+	v := New()
+	v.SetValue("name", "John")
+	v.SetValue("age", "42")
+	v.SetValue("subscribed", "TRUE")
+	v.SetValue("seats", "abc")
+
+	got, err := v.BindDynamic(map[string]string{
+		"name":       "required|min:2",
+		"age":        "required|int",
+		"subscribed": "boolean",
+		"seats":      "int",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got["name"] != "John" {
+		t.Errorf("name = %#v, want %#v", got["name"], "John")
+	}
+	if got["age"] != int64(42) {
+		t.Errorf("age = %#v, want %#v", got["age"], int64(42))
+	}
+	if got["subscribed"] != true {
+		t.Errorf("subscribed = %#v, want %#v", got["subscribed"], true)
+	}
+	if _, ok := v.Errors["seats"]; !ok {
+		t.Error("Expected error for field seats")
+	}
+	if len(v.Errors) != 1 {
+		t.Errorf("Expected exactly one error, got %v", v.Errors)
+	}
+
+	v = New()
+	v.SetValue("name", "")
+	got, err = v.BindDynamic(map[string]string{"name": "required", "age": "nope"})
+	if err == nil || got != nil {
+		t.Errorf("BindDynamic() = %v, %v, want an error for an unknown rule", got, err)
+	}
+	if len(v.Errors) != 0 {
+		t.Errorf("Expected no field errors for an invalid rule, got %v", v.Errors)
+	}
+}
+
+func TestRegisterRule(t *testing.T) {
+	RegisterRule("test_prefix", func(args []string) (ValidationFunc, error) {
+		if len(args) != 1 {
+			return nil, errors.New("expects 1 argument")
+		}
+		return Custom(func(s string) bool {
+			return strings.HasPrefix(s, args[0])
+		}, "Invalid prefix"), nil
+	})
+	defer func() {
+		customRulesMu.Lock()
+		delete(customRules, "test_prefix")
+		customRulesMu.Unlock()
+	}()
+
+	validations, err := ParseRules("required|test_prefix:sku-")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	v := New()
+	v.SetValue("sku", "item-1")
+	v.String("sku", validations...)
+	if v.Errors["sku"] != "Invalid prefix" {
+		t.Errorf("Expected custom rule error, got %v", v.Errors)
+	}
+
+	if _, err := ParseRules("test_prefix"); err == nil {
+		t.Error("Expected factory error to be returned")
+	}
+
+	assertPanics(t, "duplicate custom rule", func() {
+		RegisterRule("test_prefix", func(args []string) (ValidationFunc, error) { return Required, nil })
+	})
+	assertPanics(t, "built-in rule", func() {
+		RegisterRule("required", func(args []string) (ValidationFunc, error) { return Required, nil })
+	})
+}
+
+func TestOverrideRule(t *testing.T) {
+	OverrideRule("email", func(args []string) (ValidationFunc, error) {
+		return Custom(func(s string) bool { return true }, ""), nil
+	})
+	defer func() {
+		customRulesMu.Lock()
+		delete(customRules, "email")
+		customRulesMu.Unlock()
+	}()
+
+	validations, err := ParseRules("email")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ok, _ := validations[0]("email", "not-an-email"); !ok {
+		t.Error("Expected overridden rule to be used")
+	}
+}
+
+func assertPanics(t *testing.T, name string, fn func()) {
+	t.Helper()
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("%s: expected panic", name)
+		}
+	}()
+	fn()
+}
+
+func TestValidateRows(t *testing.T) {
+	rows := []map[string]string{
+		{"email": "john@example.com", "name": "John"},
+		{"email": "not-an-email", "name": "Jane"},
+		{"name": "J"},
+	}
+
+	validators, err := ValidateRows(rows, Rules{
+		"email": "required|email",
+		"name":  "required|min:2",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(validators) != len(rows) {
+		t.Fatalf("ValidateRows() returned %d validators, want %d", len(validators), len(rows))
+	}
+	if !validators[0].Valid() {
+		t.Errorf("row 0: unexpected errors %v", validators[0].Errors)
+	}
+	if _, ok := validators[1].Errors["email"]; !ok || len(validators[1].Errors) != 1 {
+		t.Errorf("row 1: expected a single email error, got %v", validators[1].Errors)
+	}
+	if len(validators[2].Errors) != 2 {
+		t.Errorf("row 2: expected email and name errors, got %v", validators[2].Errors)
+	}
+	if validators[1].GetValue("name") != "Jane" {
+		t.Error("Expected row values to be loaded")
+	}
+
+	validators, err = ValidateRows(rows[:1], Rules{"email": "nope"})
+	if err == nil || validators != nil {
+		t.Errorf("ValidateRows() = %v, %v, want an error for an unknown rule", validators, err)
+	}
+}
@@ -0,0 +1,302 @@
+package form_validator
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// maxFirstLineLength caps how much of a file is read when looking for its first line.
+const maxFirstLineLength = 4 * KB
+
+// CSVRowLimit validates that an uploaded CSV file has at most max rows.
+// Reading stops as soon as the limit is exceeded.
+func (v *Validator) CSVRowLimit(field string, max int) {
+	file := v.GetFile(field)
+	if file == nil {
+		v.addError(field, "No file was uploaded")
+		return
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		v.addError(field, "Could not process file")
+		return
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	rows := 0
+	for {
+		_, err := reader.Read()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			v.addError(field, "File is not a valid CSV")
+			return
+		}
+
+		rows++
+		if rows > max {
+			v.addError(field, fmt.Sprintf("File exceeds maximum of %d rows", max))
+			return
+		}
+	}
+}
+
+// FileFirstLine validates that the first line of an uploaded file matches a regex
+// pattern (e.g. a "#!/bin/bash" shebang).
+func (v *Validator) FileFirstLine(field, pattern string) {
+	regex, err := compilePattern(pattern)
+	if err != nil {
+		v.addError(field, "Invalid validation pattern")
+		return
+	}
+
+	file := v.GetFile(field)
+	if file == nil {
+		v.addError(field, "No file was uploaded")
+		return
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		v.addError(field, "Could not process file")
+		return
+	}
+	defer f.Close()
+
+	line, err := bufio.NewReader(io.LimitReader(f, maxFirstLineLength+1)).ReadBytes('\n')
+	if err != nil && err != io.EOF {
+		v.addError(field, "Could not read file content")
+		return
+	}
+
+	if err == io.EOF && len(line) > maxFirstLineLength {
+		v.addError(field, fmt.Sprintf("First line exceeds maximum length of %d bytes", maxFirstLineLength))
+		return
+	}
+
+	line = bytes.TrimRight(line, "\r\n")
+	if !regex.Match(line) {
+		v.addError(field, "File does not start with the expected header")
+	}
+}
+
+// SafeZip validates that an uploaded zip archive has at most maxEntries entries and
+// that none of them would be extracted outside the destination directory. Only the
+// central directory is read; entries are never extracted.
+func (v *Validator) SafeZip(field string, maxEntries int) {
+	file := v.GetFile(field)
+	if file == nil {
+		v.addError(field, "No file was uploaded")
+		return
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		v.addError(field, "Could not process file")
+		return
+	}
+	defer f.Close()
+
+	archive, err := zip.NewReader(f, file.Size)
+	if errors.Is(err, zip.ErrInsecurePath) {
+		v.addError(field, "Archive contains unsafe file paths")
+		return
+	}
+	if err != nil {
+		v.addError(field, "File is not a valid zip archive")
+		return
+	}
+
+	if len(archive.File) > maxEntries {
+		v.addError(field, fmt.Sprintf("Archive exceeds maximum of %d entries", maxEntries))
+		return
+	}
+
+	for _, entry := range archive.File {
+		if !isSafeArchivePath(entry.Name) {
+			v.addError(field, "Archive contains unsafe file paths")
+			return
+		}
+	}
+}
+
+// Main parts identifying Office Open XML documents.
+var officeMainParts = map[string]string{
+	"word/document.xml": MimeDOCX,
+	"xl/workbook.xml":   MimeXLSX,
+}
+
+// officeType returns the Office Open XML type of a zip archive, or MimeZIP when
+// the archive lacks a [Content_Types].xml part or a known main part.
+func officeType(r io.ReaderAt, size int64) string {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return MimeZIP
+	}
+
+	hasContentTypes := false
+	mimeType := ""
+	for _, entry := range archive.File {
+		if entry.Name == "[Content_Types].xml" {
+			hasContentTypes = true
+		}
+		if t, ok := officeMainParts[entry.Name]; ok {
+			mimeType = t
+		}
+	}
+
+	if !hasContentTypes || mimeType == "" {
+		return MimeZIP
+	}
+
+	return mimeType
+}
+
+// isSafeArchivePath reports whether an archive entry name stays within the extraction directory.
+func isSafeArchivePath(name string) bool {
+	name = strings.ReplaceAll(name, "\\", "/")
+	if name == "" || path.IsAbs(name) || (len(name) >= 2 && name[1] == ':') {
+		return false
+	}
+
+	for _, segment := range strings.Split(name, "/") {
+		if segment == ".." {
+			return false
+		}
+	}
+
+	return true
+}
+
+// UniqueFileNames validates that the files of a multi-file field have distinct names.
+// Names are compared without their directory and ignoring case.
+func (v *Validator) UniqueFileNames(field string) {
+	seen := make(map[string]bool)
+
+	for _, file := range v.GetFiles(field) {
+		name := strings.ToLower(path.Base(strings.ReplaceAll(strings.TrimSpace(file.Filename), "\\", "/")))
+		if seen[name] {
+			v.addError(field, "Duplicate file names are not allowed")
+			return
+		}
+		seen[name] = true
+	}
+}
+
+// PDF structures used to count pages. "/Type /Pages" tree nodes are excluded from
+// pdfPageRegex.
+var (
+	pdfPageRegex  = regexp.MustCompile(`/Type\s*/Page\b`)
+	pdfRootRegex  = regexp.MustCompile(`/Root\s+(\d+)\s+(\d+)\s+R\b`)
+	pdfPagesRegex = regexp.MustCompile(`/Pages\s+(\d+)\s+(\d+)\s+R\b`)
+	pdfCountRegex = regexp.MustCompile(`/Count\s+(\d+)`)
+)
+
+// PDFPageLimit validates that an uploaded PDF has at most max pages. This is a
+// lightweight parse rather than a full PDF reader, and it can be bypassed. The
+// page count is read from /Count in the root page tree node. If that node is
+// stored in a compressed object stream, the visible "/Type /Page" objects are
+// counted instead, which is only a lower bound. A crafted document can
+// compress its pages to pass any limit.
+func (v *Validator) PDFPageLimit(field string, max int) {
+	file := v.GetFile(field)
+	if file == nil {
+		v.addError(field, "No file was uploaded")
+		return
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		v.addError(field, "Could not process file")
+		return
+	}
+	defer f.Close()
+
+	content, err := io.ReadAll(f)
+	if err != nil {
+		v.addError(field, "Could not read file content")
+		return
+	}
+
+	if !bytes.HasPrefix(content, []byte("%PDF-")) {
+		v.addError(field, "File is not a valid PDF")
+		return
+	}
+
+	pages, ok := pdfRootPageCount(content)
+	if !ok {
+		pages = len(pdfPageRegex.FindAllIndex(content, -1))
+	}
+	if pages == 0 {
+		v.addError(field, "Could not count the pages of the document")
+		return
+	}
+
+	if pages > max {
+		v.addError(field, fmt.Sprintf("Document exceeds %d pages", max))
+	}
+}
+
+// pdfRootPageCount reads /Count from the root page tree node, following the last
+// trailer's /Root reference so that incremental updates are taken into account.
+func pdfRootPageCount(content []byte) (int, bool) {
+	roots := pdfRootRegex.FindAllSubmatch(content, -1)
+	if len(roots) == 0 {
+		return 0, false
+	}
+	root := roots[len(roots)-1]
+
+	catalog, ok := pdfObject(content, root[1], root[2])
+	if !ok {
+		return 0, false
+	}
+
+	pagesRef := pdfPagesRegex.FindSubmatch(catalog)
+	if pagesRef == nil {
+		return 0, false
+	}
+
+	pages, ok := pdfObject(content, pagesRef[1], pagesRef[2])
+	if !ok {
+		return 0, false
+	}
+
+	count := pdfCountRegex.FindSubmatch(pages)
+	if count == nil {
+		return 0, false
+	}
+
+	n, err := strconv.Atoi(string(count[1]))
+	if err != nil {
+		return 0, false
+	}
+
+	return n, true
+}
+
+// pdfObject returns the body of the last definition of an indirect object.
+func pdfObject(content, number, generation []byte) ([]byte, bool) {
+	objRegex := regexp.MustCompile(`(?s)(?:^|[^0-9])` + string(number) + `\s+` + string(generation) + `\s+obj\b(.*?)endobj`)
+
+	matches := objRegex.FindAllSubmatch(content, -1)
+	if len(matches) == 0 {
+		return nil, false
+	}
+
+	return matches[len(matches)-1][1], true
+}
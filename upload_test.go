@@ -0,0 +1,202 @@
+package form_validator
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"strings"
+	"testing"
+)
+
+func TestValidator_CSVRowLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{"under limit", "a,b\n1,2\n", false},
+		{"at limit", "a,b\n1,2\n3,4\n", false},
+		{"over limit", "a,b\n1,2\n3,4\n5,6\n", true},
+		{"empty file", "", false},
+		{"malformed", "a,\"b\n1,2\n", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New()
+			v.SetFile("import", createFileHeader(t, "import", "import.csv", []byte(tt.content)))
+			v.CSVRowLimit("import", 3)
+
+			if _, ok := v.Errors["import"]; ok != tt.wantErr {
+				t.Errorf("error present = %v, want %v (errors: %v)", ok, tt.wantErr, v.Errors)
+			}
+		})
+	}
+
+	v := New()
+	v.CSVRowLimit("import", 3)
+	if _, ok := v.Errors["import"]; !ok {
+		t.Error("Expected error when no file was uploaded")
+	}
+}
+
+func TestValidator_FileFirstLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{"shebang", "#!/bin/bash\necho hi\n", false},
+		{"windows line ending", "#!/bin/bash\r\necho hi\r\n", false},
+		{"single line", "#!/bin/bash", false},
+		{"wrong interpreter", "#!/usr/bin/env python\nprint()\n", true},
+		{"empty file", "", true},
+		{"line too long", "#!/bin/bash" + strings.Repeat(" ", 5000), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New()
+			v.SetFile("script", createFileHeader(t, "script", "run.sh", []byte(tt.content)))
+			v.FileFirstLine("script", `^#!/bin/bash\s*$`)
+
+			if _, ok := v.Errors["script"]; ok != tt.wantErr {
+				t.Errorf("error present = %v, want %v (errors: %v)", ok, tt.wantErr, v.Errors)
+			}
+		})
+	}
+
+	v := New()
+	v.SetFile("script", createFileHeader(t, "script", "run.sh", []byte("#!/bin/bash\n")))
+	v.FileFirstLine("script", `^#!(/bin/bash`)
+	if _, ok := v.Errors["script"]; !ok {
+		t.Error("Expected error for an invalid pattern")
+	}
+}
+
+// createZip builds a zip archive holding empty entries with the given names.
+func createZip(t *testing.T, names ...string) []byte {
+	t.Helper()
+
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for _, name := range names {
+		if _, err := writer.Create(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buffer.Bytes()
+}
+
+func TestValidator_SafeZip(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []byte
+		wantErr bool
+	}{
+		{"safe entries", createZip(t, "a.txt", "docs/b.txt"), false},
+		{"at entry limit", createZip(t, "a", "b", "c"), false},
+		{"too many entries", createZip(t, "a", "b", "c", "d"), true},
+		{"parent traversal", createZip(t, "../evil.sh"), true},
+		{"nested traversal", createZip(t, "docs/../../evil.sh"), true},
+		{"windows traversal", createZip(t, "docs\\..\\..\\evil.sh"), true},
+		{"absolute path", createZip(t, "/etc/passwd"), true},
+		{"drive letter", createZip(t, "C:/Windows/evil.dll"), true},
+		{"not a zip", []byte("plain text"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New()
+			v.SetFile("bundle", createFileHeader(t, "bundle", "bundle.zip", tt.content))
+			v.SafeZip("bundle", 3)
+
+			if _, ok := v.Errors["bundle"]; ok != tt.wantErr {
+				t.Errorf("error present = %v, want %v (errors: %v)", ok, tt.wantErr, v.Errors)
+			}
+		})
+	}
+}
+
+func TestValidator_UniqueFileNames(t *testing.T) {
+	tests := []struct {
+		name      string
+		filenames []string
+		wantErr   bool
+	}{
+		{"distinct names", []string{"a.jpg", "b.jpg"}, false},
+		{"single file", []string{"a.jpg"}, false},
+		{"duplicate names", []string{"a.jpg", "b.jpg", "a.jpg"}, true},
+		{"duplicate ignoring case", []string{"Photo.JPG", "photo.jpg"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := make([]*multipart.FileHeader, 0)
+			for _, filename := range tt.filenames {
+				files = append(files, createFileHeader(t, "photos", filename, []byte("content")))
+			}
+
+			v := New()
+			v.SetFiles("photos", files)
+			v.UniqueFileNames("photos")
+
+			if _, ok := v.Errors["photos"]; ok != tt.wantErr {
+				t.Errorf("error present = %v, want %v (errors: %v)", ok, tt.wantErr, v.Errors)
+			}
+		})
+	}
+}
+
+// createPDF builds a minimal uncompressed PDF with the given number of pages.
+func createPDF(pages int) []byte {
+	var buffer bytes.Buffer
+	buffer.WriteString("%PDF-1.4\n1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+	fmt.Fprintf(&buffer, "2 0 obj\n<< /Type /Pages /Count %d >>\nendobj\n", pages)
+	for i := 0; i < pages; i++ {
+		fmt.Fprintf(&buffer, "%d 0 obj\n<</Type/Page/Parent 2 0 R>>\nendobj\n", i+3)
+	}
+	buffer.WriteString("trailer\n<< /Root 1 0 R >>\n%%EOF\n")
+
+	return buffer.Bytes()
+}
+
+func TestValidator_PDFPageLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []byte
+		wantErr bool
+	}{
+		{"single page", createPDF(1), false},
+		{"at page limit", createPDF(3), false},
+		{"too many pages", createPDF(4), true},
+		{"no pages", createPDF(0), true},
+		// Page objects hidden in an object stream still count through the page tree.
+		{"compressed pages", []byte("%PDF-1.5\n1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n" +
+			"2 0 obj\n<< /Type /Pages /Count 5 >>\nendobj\n3 0 obj\n<</Type/Page/Parent 2 0 R>>\nendobj\n" +
+			"trailer\n<< /Root 1 0 R >>\n%%EOF\n"), true},
+		// An incremental update repeats page objects without adding pages.
+		{"incremental update", append(createPDF(3), []byte("2 0 obj\n<< /Type /Pages /Count 3 >>\nendobj\n"+
+			"3 0 obj\n<</Type/Page/Parent 2 0 R>>\nendobj\n4 0 obj\n<</Type/Page/Parent 2 0 R>>\nendobj\n"+
+			"trailer\n<< /Root 1 0 R /Prev 0 >>\n%%EOF\n")...), false},
+		{"no page tree", []byte("%PDF-1.4\n1 0 obj\n<</Type/Page>>\nendobj\n%%EOF\n"), false},
+		{"not a pdf", []byte("plain text"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New()
+			v.SetFile("document", createFileHeader(t, "document", "document.pdf", tt.content))
+			v.PDFPageLimit("document", 3)
+
+			if _, ok := v.Errors["document"]; ok != tt.wantErr {
+				t.Errorf("error present = %v, want %v (errors: %v)", ok, tt.wantErr, v.Errors)
+			}
+		})
+	}
+}
@@ -1,15 +1,32 @@
 package form_validator
 
 import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io"
+	"math"
+	"mime"
 	"mime/multipart"
+	"net"
 	"net/http"
+	"net/netip"
+	"net/url"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
+	"unicode"
 	"unicode/utf8"
+
+	"golang.org/x/crypto/sha3"
+	"golang.org/x/text/encoding/ianaindex"
+	"golang.org/x/text/unicode/norm"
 )
 
 // Add new types and constants for file validation.
@@ -17,6 +34,25 @@ type FileValidationConfig struct {
 	MaxSize      int64    // maximum file size in bytes.
 	AllowedTypes []string // allowed MIME types.
 	AllowedExts  []string // allowed file extensions.
+	RejectGPS    bool     // reject JPEG images carrying GPS EXIF metadata.
+	DeepScan     bool     // check the end of JPEG and PNG files to catch truncated uploads.
+
+	// MinBytesPerPixel rejects images whose file size is suspiciously small for their
+	// dimensions, a cheap heuristic against decompression bombs. Tune it to the images
+	// you expect: heavily compressed but legitimate images (e.g. flat PNG graphics)
+	// can fall below 0.01. Formats whose dimensions cannot be read are not checked.
+	MinBytesPerPixel float64
+
+	ForbiddenSignatures [][]byte // byte sequences not allowed in the first 512 bytes.
+
+	// ExtTypes, when set, maps each allowed extension to the MIME type its content
+	// must have, so that a file cannot pass with another allowed type's extension.
+	ExtTypes map[string]string
+
+	// StripMetadata makes Image re-encode the image without its metadata, applying
+	// the EXIF orientation, and rejects images that cannot be processed. The result
+	// is returned by ProcessedImage for the same field.
+	StripMetadata bool
 }
 
 // Common MIME types for images.
@@ -27,14 +63,76 @@ const (
 	MimeWEBP = "image/webp"
 )
 
+// Common MIME types for documents, as detected from their content. CSV files are
+// detected as plain text. Zip containers are reported as Office Open XML documents
+// when they hold a [Content_Types].xml part and the matching main part.
+const (
+	MimePDF  = "application/pdf"
+	MimeText = "text/plain"
+	MimeZIP  = "application/zip"
+	MimeDOCX = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	MimeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// FileCategory groups MIME types into broad upload categories.
+type FileCategory string
+
+// Common file categories.
+const (
+	CategoryImage    FileCategory = "image"
+	CategoryVideo    FileCategory = "video"
+	CategoryAudio    FileCategory = "audio"
+	CategoryDocument FileCategory = "document"
+)
+
+// categoryTypes maps categories to the MIME type prefixes they accept.
+// OpenDocument files are detected as zip containers.
+var categoryTypes = map[FileCategory][]string{
+	CategoryImage:    {"image/"},
+	CategoryVideo:    {"video/"},
+	CategoryAudio:    {"audio/"},
+	CategoryDocument: {MimePDF, MimeText, MimeZIP, MimeDOCX, MimeXLSX},
+}
+
+// ExecutableSignatures holds the magic numbers of common executable formats, for
+// use as FileValidationConfig.ForbiddenSignatures.
+var ExecutableSignatures = [][]byte{
+	[]byte("\x7fELF"),          // ELF (Linux).
+	[]byte("MZ\x90\x00"),       // PE (Windows).
+	[]byte("\xcf\xfa\xed\xfe"), // Mach-O 64-bit (macOS).
+	[]byte("\xca\xfe\xba\xbe"), // Mach-O universal binary (macOS).
+}
+
 // Default image formats.
 var DefaultImageFormats = []string{"jpg", "jpeg", "png", "gif", "webp"}
 
+// Default document formats.
+var DefaultDocumentFormats = []string{"pdf", "csv", "docx", "xlsx"}
+
 // Validator holds the validation errors and form values.
 type Validator struct {
-	Errors map[string]string
-	values map[string]string
-	files  map[string]*multipart.FileHeader
+	Errors          map[string]string
+	AllErrors       map[string][]string
+	Warnings        map[string]string
+	values          map[string][]string
+	files           map[string][]*multipart.FileHeader
+	processedImages map[string][]byte
+	defaultMessage  string
+	onInvalid       []func(errors map[string]string)
+	remoteIP        func() string
+
+	// CollectAll makes String, Int and Float run every validation function instead
+	// of stopping at the first failure. All messages are kept in AllErrors.
+	CollectAll bool
+
+	// AutoTrim makes SetValue and SetValues strip leading and trailing whitespace,
+	// so String and the other getters return trimmed values.
+	AutoTrim bool
+
+	// AutoTrimSkip lists the fields AutoTrim leaves as is. Password fields should be
+	// listed here, since spaces can be part of a secret; a listed field can still be
+	// trimmed explicitly with SetValueTrimmed.
+	AutoTrimSkip []string
 }
 
 // Common file size constants.
@@ -46,32 +144,169 @@ const (
 // ValidationFunc represents a validation function.
 type ValidationFunc func(field, value string) (bool, string)
 
+// ContextValidationFunc represents a validation function that can read the other form values.
+type ContextValidationFunc func(v *Validator, field, value string) (bool, string)
+
 // New creates a new validator instance.
 func New() *Validator {
 	return &Validator{
-		Errors: make(map[string]string),
-		values: make(map[string]string),
-		files:  make(map[string]*multipart.FileHeader),
+		Errors:    make(map[string]string),
+		AllErrors: make(map[string][]string),
+		Warnings:  make(map[string]string),
+		values:    make(map[string][]string),
+		files:     make(map[string][]*multipart.FileHeader),
+
+		processedImages: make(map[string][]byte),
+		defaultMessage:  "Invalid value",
+	}
+}
+
+// SetDefaultMessage sets the message used when a failed check provides no message.
+func (v *Validator) SetDefaultMessage(msg string) {
+	v.defaultMessage = msg
+}
+
+// addError records an error message for a field, falling back to the default message.
+// Errors keeps the latest message while AllErrors keeps every distinct message, so a
+// parse failure already reported by a validation function is not listed twice.
+func (v *Validator) addError(field, message string) {
+	if message == "" {
+		message = v.defaultMessage
+	}
+
+	v.Errors[field] = message
+	for _, existing := range v.AllErrors[field] {
+		if existing == message {
+			return
+		}
+	}
+	v.AllErrors[field] = append(v.AllErrors[field], message)
+}
+
+// ErrorsFor returns every error message recorded for a field.
+func (v *Validator) ErrorsFor(field string) []string {
+	return v.AllErrors[field]
+}
+
+// FieldError is the error recorded for a single field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// SortedErrors returns the errors sorted by field name, for deterministic output
+// such as JSON responses.
+func (v *Validator) SortedErrors() []FieldError {
+	fields := make([]string, 0, len(v.Errors))
+	for field := range v.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	errors := make([]FieldError, 0, len(fields))
+	for _, field := range fields {
+		errors = append(errors, FieldError{Field: field, Message: v.Errors[field]})
+	}
+
+	return errors
+}
+
+// validate runs the validation functions on a value, stopping at the first
+// failure unless CollectAll is set.
+func (v *Validator) validate(field, value string, validations []ValidationFunc) {
+	for _, validation := range validations {
+		if ok, message := validation(field, value); !ok {
+			v.addError(field, message)
+			if !v.CollectAll {
+				break
+			}
+		}
 	}
 }
 
 // SetValue sets a form value.
 func (v *Validator) SetValue(field, value string) {
-	v.values[field] = value
+	v.values[field] = []string{v.autoTrim(field, value)}
 }
 
-// GetValue gets a form value.
+// SetValueTrimmed sets a form value with leading and trailing whitespace removed,
+// regardless of AutoTrim.
+func (v *Validator) SetValueTrimmed(field, value string) {
+	v.values[field] = []string{strings.TrimSpace(value)}
+}
+
+// autoTrim trims a value if AutoTrim applies to the field.
+func (v *Validator) autoTrim(field, value string) string {
+	if !v.AutoTrim {
+		return value
+	}
+
+	for _, skipped := range v.AutoTrimSkip {
+		if skipped == field {
+			return value
+		}
+	}
+
+	return strings.TrimSpace(value)
+}
+
+// GetValue gets a form value. For multi-value fields, the first value is returned.
 func (v *Validator) GetValue(field string) string {
+	if values := v.values[field]; len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// SetValues sets all the values of a multi-value field (e.g. a checkbox group).
+// The values are copied, so transforms such as NormalizeNFC never modify the
+// caller's slice or the request form.
+func (v *Validator) SetValues(field string, values []string) {
+	copied := make([]string, len(values))
+	for i, value := range values {
+		copied[i] = v.autoTrim(field, value)
+	}
+
+	v.values[field] = copied
+}
+
+// GetValues gets all the values of a multi-value field.
+func (v *Validator) GetValues(field string) []string {
 	return v.values[field]
 }
 
+// NormalizeNFC converts the values of the given fields to Unicode Normalization
+// Form C, so composed and decomposed accents compare equal.
+func (v *Validator) NormalizeNFC(fields ...string) {
+	for _, field := range fields {
+		for i, value := range v.values[field] {
+			v.values[field][i] = norm.NFC.String(value)
+		}
+	}
+}
+
 // Add method to set file.
 func (v *Validator) SetFile(field string, file *multipart.FileHeader) {
-	v.files[field] = file
+	v.files[field] = []*multipart.FileHeader{file}
 }
 
-// Add method to get file.
+// Add method to get file. For multi-file fields, the first file is returned.
 func (v *Validator) GetFile(field string) *multipart.FileHeader {
+	if files := v.files[field]; len(files) > 0 {
+		return files[0]
+	}
+
+	return nil
+}
+
+// SetFiles sets all the files of a multi-file field.
+func (v *Validator) SetFiles(field string, files []*multipart.FileHeader) {
+	v.files[field] = files
+}
+
+// GetFiles gets all the files of a multi-file field.
+func (v *Validator) GetFiles(field string) []*multipart.FileHeader {
 	return v.files[field]
 }
 
@@ -108,18 +343,168 @@ func ImageConfig(maxSize int64, formats ...string) FileValidationConfig {
 	}
 }
 
+// DocumentConfig creates a configuration for document uploads. Each extension
+// only accepts content of its own format. It panics on an unknown format.
+func DocumentConfig(maxSize int64, formats ...string) FileValidationConfig {
+	if len(formats) == 0 {
+		formats = DefaultDocumentFormats
+	}
+
+	mimeTypes := make([]string, 0)
+	extensions := make([]string, 0)
+	extTypes := make(map[string]string)
+
+	for _, format := range formats {
+		var mimeType, extension string
+		switch strings.ToLower(format) {
+		case "pdf":
+			mimeType, extension = MimePDF, ".pdf"
+		case "csv":
+			mimeType, extension = MimeText, ".csv"
+		case "docx":
+			mimeType, extension = MimeDOCX, ".docx"
+		case "xlsx":
+			mimeType, extension = MimeXLSX, ".xlsx"
+		default:
+			panic(fmt.Sprintf("form_validator: unknown document format %q", format))
+		}
+
+		if _, ok := extTypes[extension]; ok {
+			continue
+		}
+		mimeTypes = append(mimeTypes, mimeType)
+		extensions = append(extensions, extension)
+		extTypes[extension] = mimeType
+	}
+
+	return FileValidationConfig{
+		MaxSize:      maxSize,
+		AllowedTypes: mimeTypes,
+		AllowedExts:  extensions,
+		ExtTypes:     extTypes,
+	}
+}
+
+// CategoryConfig creates a validation configuration accepting any type in the given categories.
+func CategoryConfig(maxSize int64, cats ...FileCategory) FileValidationConfig {
+	mimeTypes := make([]string, 0)
+	for _, cat := range cats {
+		mimeTypes = append(mimeTypes, categoryTypes[cat]...)
+	}
+
+	return FileValidationConfig{
+		MaxSize:      maxSize,
+		AllowedTypes: mimeTypes,
+	}
+}
+
 // Image validates an image file field.
 func (v *Validator) Image(field string, config FileValidationConfig) *multipart.FileHeader {
-	file := v.files[field]
-	if file == nil {
-		v.Errors[field] = "No file was uploaded"
+	return v.image(field, v.GetFile(field), config)
+}
+
+// Images validates every image uploaded in a multi-file field and returns the
+// valid ones. Errors are recorded per file under "field[index]".
+func (v *Validator) Images(field string, config FileValidationConfig) []*multipart.FileHeader {
+	files := v.GetFiles(field)
+	if len(files) == 0 {
+		v.addError(field, "No file was uploaded")
+		return nil
+	}
+
+	valid := make([]*multipart.FileHeader, 0, len(files))
+	for i, file := range files {
+		if checked := v.image(fmt.Sprintf("%s[%d]", field, i), file, config); checked != nil {
+			valid = append(valid, checked)
+		}
+	}
+
+	return valid
+}
+
+// image validates a single uploaded image, recording errors on field.
+func (v *Validator) image(field string, file *multipart.FileHeader, config FileValidationConfig) *multipart.FileHeader {
+	detectedType, ok := v.file(field, file, config)
+	if !ok {
+		return nil
+	}
+
+	// Validate the size is plausible for the dimensions.
+	if config.MinBytesPerPixel > 0 {
+		f, err := file.Open()
+		if err != nil {
+			v.addError(field, "Could not process file")
+			return nil
+		}
+		defer f.Close()
+
+		if !hasPlausibleSize(f, file.Size, config.MinBytesPerPixel) {
+			v.addError(field, "Image failed integrity checks")
+			return nil
+		}
+	}
+
+	// Validate location metadata.
+	if config.RejectGPS && detectedType == MimeJPEG {
+		f, err := file.Open()
+		if err != nil {
+			v.addError(field, "Could not process file")
+			return nil
+		}
+		defer f.Close()
+
+		metadata, err := readEXIF(f)
+		if err != nil {
+			v.addError(field, "Could not read image metadata")
+			return nil
+		}
+
+		if metadata.hasGPS {
+			v.addError(field, "Image must not contain location metadata")
+			return nil
+		}
+	}
+
+	// Re-encode the image without its metadata.
+	if config.StripMetadata {
+		maxSize := file.Size
+		if config.MaxSize > 0 && config.MaxSize < maxSize {
+			maxSize = config.MaxSize
+		}
+
+		processed, err := processImage(file, maxSize)
+		if err != nil {
+			v.addError(field, "Could not process image")
+			return nil
+		}
+		v.processedImages[field] = processed
+	}
+
+	return file
+}
+
+// File validates the size, extension and content type of an uploaded file.
+func (v *Validator) File(field string, config FileValidationConfig) *multipart.FileHeader {
+	file := v.GetFile(field)
+	if _, ok := v.file(field, file, config); !ok {
 		return nil
 	}
 
+	return file
+}
+
+// file validates a single uploaded file, recording errors on field. It returns
+// the detected MIME type of the content.
+func (v *Validator) file(field string, file *multipart.FileHeader, config FileValidationConfig) (string, bool) {
+	if file == nil {
+		v.addError(field, "No file was uploaded")
+		return "", false
+	}
+
 	// Validate file size.
 	if config.MaxSize > 0 && file.Size > config.MaxSize {
-		v.Errors[field] = fmt.Sprintf("File size exceeds maximum limit of %d bytes", config.MaxSize)
-		return nil
+		v.addError(field, fmt.Sprintf("File size exceeds maximum limit of %d bytes", config.MaxSize))
+		return "", false
 	}
 
 	// Validate file extension.
@@ -134,28 +519,41 @@ func (v *Validator) Image(field string, config FileValidationConfig) *multipart.
 		}
 
 		if !validExt {
-			v.Errors[field] = fmt.Sprintf("Invalid file extension. Allowed: %s", strings.Join(config.AllowedExts, ", "))
-			return nil
+			v.addError(field, fmt.Sprintf("Invalid file extension. Allowed: %s", strings.Join(config.AllowedExts, ", ")))
+			return "", false
 		}
 	}
 
 	// Validate MIME type.
 	f, err := file.Open()
 	if err != nil {
-		v.Errors[field] = "Could not process file"
-		return nil
+		v.addError(field, "Could not process file")
+		return "", false
 	}
 	defer f.Close()
 
 	// Read first 512 bytes for MIME type detection.
 	buffer := make([]byte, 512)
-	_, err = f.Read(buffer)
-	if err != nil && err != io.EOF {
-		v.Errors[field] = "Could not read file content"
-		return nil
+	n, err := io.ReadFull(f, buffer)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		v.addError(field, "Could not read file content")
+		return "", false
+	}
+	buffer = buffer[:n]
+
+	// Reject embedded executables and other forbidden content.
+	for _, signature := range config.ForbiddenSignatures {
+		if len(signature) > 0 && bytes.Contains(buffer, signature) {
+			v.addError(field, "File content is not allowed")
+			return "", false
+		}
 	}
 
 	detectedType := http.DetectContentType(buffer)
+	if detectedType == MimeZIP {
+		detectedType = officeType(f, file.Size)
+	}
+
 	if len(config.AllowedTypes) > 0 {
 		validType := false
 		for _, allowedType := range config.AllowedTypes {
@@ -166,196 +564,2213 @@ func (v *Validator) Image(field string, config FileValidationConfig) *multipart.
 		}
 
 		if !validType {
-			v.Errors[field] = fmt.Sprintf("Invalid file type. Allowed: %s", strings.Join(config.AllowedTypes, ", "))
-			return nil
+			v.addError(field, fmt.Sprintf("Invalid file type. Allowed: %s", strings.Join(config.AllowedTypes, ", ")))
+			return "", false
 		}
 	}
 
-	return file
+	// Validate the content matches the extension.
+	if config.ExtTypes != nil && !strings.HasPrefix(detectedType, config.ExtTypes[ext]) {
+		v.addError(field, "File content does not match its extension")
+		return "", false
+	}
+
+	// Validate the file is complete.
+	if config.DeepScan && !hasValidTrailer(f, file.Size, detectedType) {
+		v.addError(field, "File appears truncated or corrupt")
+		return "", false
+	}
+
+	return detectedType, true
 }
 
 // String validates a string field with the given validation functions
 func (v *Validator) String(field string, validations ...ValidationFunc) string {
 	value := v.GetValue(field)
 
-	for _, validation := range validations {
-		if ok, message := validation(field, value); !ok {
-			v.Errors[field] = message
-			break
-		}
-	}
+	v.validate(field, value, validations)
 
 	return value
 }
 
 // Int validates and returns an integer field.
 func (v *Validator) Int(field string, validations ...ValidationFunc) int64 {
+	intValue, _ := v.IntOK(field, validations...)
+	return intValue
+}
+
+// IntOK validates and returns an integer field, along with whether it could be
+// parsed. This tells a legitimate 0 apart from a parse failure.
+func (v *Validator) IntOK(field string, validations ...ValidationFunc) (int64, bool) {
 	value := v.GetValue(field)
 
-	for _, validation := range validations {
-		if ok, message := validation(field, value); !ok {
-			v.Errors[field] = message
-			break
-		}
-	}
+	v.validate(field, value, validations)
 
 	intValue, err := strconv.ParseInt(value, 10, 64)
 	if err != nil {
-		v.Errors[field] = "This field must be a valid integer"
+		v.addError(field, "This field must be a valid integer")
+		return 0, false
+	}
+
+	return intValue, true
+}
+
+// groupedIntRegex matches integers written either plainly or with commas grouping thousands.
+var groupedIntRegex = regexp.MustCompile(`^-?([1-9][0-9]{0,2}(,[0-9]{3})+|[0-9]+)$`)
+
+// IntGrouped validates and returns an integer field that may use commas as thousands
+// separators (e.g. "1,234,567"). Misplaced commas such as "12,34" are rejected.
+func (v *Validator) IntGrouped(field string) int64 {
+	value := strings.TrimSpace(v.GetValue(field))
+	if !groupedIntRegex.MatchString(value) {
+		v.addError(field, "This field must be a valid integer")
+		return 0
+	}
+
+	intValue, err := strconv.ParseInt(strings.ReplaceAll(value, ",", ""), 10, 64)
+	if err != nil {
+		v.addError(field, "This field must be a valid integer")
 		return 0
 	}
 
 	return intValue
 }
 
-// Check adds an error if the condition is false.
-func (v *Validator) Check(ok bool, field, message string) {
-	if !ok {
-		v.Errors[field] = message
-	}
+// Warn records a non-blocking warning for a field. Warnings do not affect Valid.
+func (v *Validator) Warn(field, message string) {
+	v.Warnings[field] = message
 }
 
-// Validate returns true if there are no errors.
-func (v *Validator) Valid() bool {
-	return len(v.Errors) == 0
+// AsWarning adapts a validation function so that failures are recorded as warnings
+// instead of errors, e.g. v.String("password", Required, v.AsWarning(MinLength(12))).
+func (v *Validator) AsWarning(validation ValidationFunc) ValidationFunc {
+	return func(field, value string) (bool, string) {
+		if ok, message := validation(field, value); !ok {
+			v.Warn(field, message)
+		}
+
+		return true, ""
+	}
 }
 
-// Predefined validation functions.
+// Float validates and returns a decimal number field. Only a dot is accepted as
+// decimal separator, and Inf and NaN are rejected.
+func (v *Validator) Float(field string, validations ...ValidationFunc) float64 {
+	value := v.GetValue(field)
 
-// Required validates that a field is not empty
-func Required(field, value string) (bool, string) {
-	if strings.TrimSpace(value) == "" {
-		return false, "This field is required"
+	v.validate(field, value, validations)
+
+	floatValue, ok := parseDecimal(value)
+	if !ok {
+		v.addError(field, "This field must be a valid number")
+		return 0
 	}
 
-	return true, ""
+	return floatValue
 }
 
-// MinLength creates a validation function for minimum length
-func MinLength(min int) ValidationFunc {
+// WithContext adapts a context validation function so it can be passed to String, Int, etc.
+func (v *Validator) WithContext(validation ContextValidationFunc) ValidationFunc {
 	return func(field, value string) (bool, string) {
-		if utf8.RuneCountInString(value) < min {
-			return false, fmt.Sprintf("This field must be at least %d characters long", min)
-		}
+		return validation(v, field, value)
+	}
+}
 
-		return true, ""
+// Check adds an error if the condition is false.
+func (v *Validator) Check(ok bool, field, message string) {
+	if !ok {
+		v.addError(field, message)
 	}
 }
 
-// MaxLength creates a validation function for maximum length
-func MaxLength(max int) ValidationFunc {
-	return func(field, value string) (bool, string) {
-		if utf8.RuneCountInString(value) > max {
-			return false, fmt.Sprintf("This field must not exceed %d characters", max)
+// RequiresTogether adds an error if value a is selected in a multi-value field without value b.
+func (v *Validator) RequiresTogether(field string, a, b string) {
+	selectedA, selectedB := false, false
+	for _, value := range v.GetValues(field) {
+		switch value {
+		case a:
+			selectedA = true
+		case b:
+			selectedB = true
 		}
+	}
 
-		return true, ""
+	if selectedA && !selectedB {
+		v.addError(field, fmt.Sprintf("%q requires %q to also be selected", a, b))
 	}
 }
 
-// Email validates email format
-func Email(field, value string) (bool, string) {
-	pattern := `^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`
-	regex := regexp.MustCompile(pattern)
-
-	if !regex.MatchString(value) {
-		return false, "Please enter a valid email address"
+// RequiredIf adds an error if field is empty while otherField equals otherValue
+// (e.g. "state" is required when "country" is "US").
+func (v *Validator) RequiredIf(field, otherField, otherValue string) {
+	if v.GetValue(otherField) == otherValue {
+		v.requireValue(field)
 	}
+}
 
-	return true, ""
+// RequiredUnless adds an error if field is empty unless otherField equals otherValue.
+func (v *Validator) RequiredUnless(field, otherField, otherValue string) {
+	if v.GetValue(otherField) != otherValue {
+		v.requireValue(field)
+	}
 }
 
-// Matches creates a validation function for regex pattern matching
-func Matches(pattern string, message string) ValidationFunc {
-	return func(field, value string) (bool, string) {
-		regex := regexp.MustCompile(pattern)
+// EqualField adds an error on field2 if its value differs from field1 (e.g. a
+// password confirmation). Nothing is checked while either field is empty, so a
+// missing value is left to Required; use EqualFieldStrict to compare anyway.
+func (v *Validator) EqualField(field1, field2, message string) {
+	if v.GetValue(field1) == "" || v.GetValue(field2) == "" {
+		return
+	}
 
-		if !regex.MatchString(value) {
-			return false, message
-		}
+	v.EqualFieldStrict(field1, field2, message)
+}
 
-		return true, ""
+// EqualFieldStrict adds an error on field2 if its value differs from field1, even
+// when one of them is empty. An empty message uses a default.
+func (v *Validator) EqualFieldStrict(field1, field2, message string) {
+	if message == "" {
+		message = "Fields do not match"
+	}
+
+	if v.GetValue(field1) != v.GetValue(field2) {
+		v.addError(field2, message)
 	}
 }
 
-// Boolean validates that a value is "true" or "false"
-func Boolean(field, value string) (bool, string) {
-	value = strings.TrimSpace(strings.ToLower(value))
+// requireValue adds an error if field is empty.
+func (v *Validator) requireValue(field string) {
+	if ok, message := Required(field, v.GetValue(field)); !ok {
+		v.addError(field, message)
+	}
+}
 
-	_, err := strconv.ParseBool(value)
+// Capture validates a field against a regex pattern and returns the matched groups.
+// The first element is the whole match, followed by the capture groups.
+func (v *Validator) Capture(field, pattern string) []string {
+	regex, err := compilePattern(pattern)
 	if err != nil {
-		return false, "This field must be true or false"
+		v.addError(field, "Invalid validation pattern")
+		return []string{}
 	}
 
-	return true, ""
+	matches := regex.FindStringSubmatch(v.GetValue(field))
+	if matches == nil {
+		v.addError(field, "This field does not match the required format")
+		return []string{}
+	}
+
+	return matches
 }
 
-// IntRange creates a validation function for integer range.
-func IntRange(min, max int) ValidationFunc {
+// Enum validates a field against allowed values, ignoring case and surrounding
+// whitespace, and returns the matching entry from allowed.
+func (v *Validator) Enum(field string, allowed []string) string {
+	value := strings.TrimSpace(v.GetValue(field))
+
+	for _, item := range allowed {
+		if strings.EqualFold(item, value) {
+			return item
+		}
+	}
+
+	v.addError(field, "This value is not in the allowed list")
+	return ""
+}
+
+// SumEquals validates that the numbers held in fields add up to target, recording
+// an error on each field otherwise (e.g. allocation percentages summing to 100).
+func (v *Validator) SumEquals(target float64, fields ...string) {
+	const epsilon = 1e-9
+
+	sum := 0.0
+	for _, field := range fields {
+		number, ok := parseDecimal(v.GetValue(field))
+		if !ok {
+			v.addError(field, "This field must be a valid number")
+			return
+		}
+		sum += number
+	}
+
+	if math.Abs(sum-target) > epsilon {
+		for _, field := range fields {
+			v.addError(field, fmt.Sprintf("Values must sum to %g", target))
+		}
+	}
+}
+
+// UniquePrefix validates a field with UniquePrefixOf and returns the full value it resolves to.
+func (v *Validator) UniquePrefix(field string, values []string) string {
+	resolved, ok := resolvePrefix(values, v.GetValue(field))
+	if !ok {
+		v.addError(field, "Ambiguous or unknown value")
+		return ""
+	}
+
+	return resolved
+}
+
+// CSVCount validates that a sep-separated field holds between min and max items.
+// Items are trimmed and empty items are ignored.
+func (v *Validator) CSVCount(field, sep string, min, max int) {
+	count := 0
+	for _, item := range strings.Split(v.GetValue(field), sep) {
+		if strings.TrimSpace(item) != "" {
+			count++
+		}
+	}
+
+	if count < min || count > max {
+		v.addError(field, fmt.Sprintf("Please provide between %d and %d items", min, max))
+	}
+}
+
+// IntListSum parses a sep-separated list of non-negative integers (e.g. "5,3,2") and
+// validates that their sum does not exceed maxSum. It returns nil if an item is not
+// a non-negative integer.
+func (v *Validator) IntListSum(field, sep string, maxSum int64) []int64 {
+	items := strings.Split(v.GetValue(field), sep)
+	values := make([]int64, 0, len(items))
+
+	var sum int64
+	exceeded := false
+	for _, item := range items {
+		intValue, err := strconv.ParseInt(strings.TrimSpace(item), 10, 64)
+		if err != nil || intValue < 0 {
+			v.addError(field, "This field must be a list of non-negative integers")
+			return nil
+		}
+
+		values = append(values, intValue)
+
+		// Checking before adding keeps the sum from overflowing past the cap.
+		if exceeded || intValue > maxSum-sum {
+			exceeded = true
+			continue
+		}
+		sum += intValue
+	}
+
+	if exceeded {
+		v.addError(field, fmt.Sprintf("Values must not add up to more than %d", maxSum))
+	}
+
+	return values
+}
+
+// Validate returns true if there are no errors.
+func (v *Validator) Valid() bool {
+	return len(v.Errors) == 0
+}
+
+// IfValid runs fn only when there are no errors.
+func (v *Validator) IfValid(fn func()) {
+	if v.Valid() {
+		fn()
+	}
+}
+
+// IfValidElse runs ok when there are no errors, and fail otherwise.
+func (v *Validator) IfValidElse(ok func(), fail func()) {
+	if v.Valid() {
+		ok()
+	} else {
+		fail()
+	}
+}
+
+// OnInvalid registers a callback run by Finish when validation has failed.
+func (v *Validator) OnInvalid(fn func(errors map[string]string)) {
+	v.onInvalid = append(v.onInvalid, fn)
+}
+
+// Finish returns true if there are no errors. Otherwise, it runs the OnInvalid
+// callbacks with the collected errors and returns false.
+func (v *Validator) Finish() bool {
+	if v.Valid() {
+		return true
+	}
+
+	for _, fn := range v.onInvalid {
+		fn(v.Errors)
+	}
+
+	return false
+}
+
+// WriteJSON writes the validation result as {"valid": bool, "errors": {...}}.
+// The status is used when there are errors (e.g. http.StatusUnprocessableEntity),
+// and a status outside 100-999 is replaced with http.StatusUnprocessableEntity;
+// a valid result is always written with http.StatusOK. The returned error reports
+// a failure to encode or write the body, after the status has been sent.
+func (v *Validator) WriteJSON(w http.ResponseWriter, status int) error {
+	valid := v.Valid()
+	if valid {
+		status = http.StatusOK
+	} else if status < 100 || status > 999 {
+		status = http.StatusUnprocessableEntity
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+
+	return json.NewEncoder(w).Encode(struct {
+		Valid  bool              `json:"valid"`
+		Errors map[string]string `json:"errors"`
+	}{valid, v.Errors})
+}
+
+// Predefined validation functions.
+
+// Required validates that a field is not empty
+func Required(field, value string) (bool, string) {
+	if strings.TrimSpace(value) == "" {
+		return false, "This field is required"
+	}
+
+	return true, ""
+}
+
+// MinLength creates a validation function for minimum length
+func MinLength(min int) ValidationFunc {
+	return func(field, value string) (bool, string) {
+		if utf8.RuneCountInString(value) < min {
+			return false, fmt.Sprintf("This field must be at least %d characters long", min)
+		}
+
+		return true, ""
+	}
+}
+
+// MaxLength creates a validation function for maximum length
+func MaxLength(max int) ValidationFunc {
+	return func(field, value string) (bool, string) {
+		if utf8.RuneCountInString(value) > max {
+			return false, fmt.Sprintf("This field must not exceed %d characters", max)
+		}
+
+		return true, ""
+	}
+}
+
+// MinWords creates a validation function for a minimum number of words
+func MinWords(min int) ValidationFunc {
+	return func(field, value string) (bool, string) {
+		if len(strings.Fields(value)) < min {
+			return false, fmt.Sprintf("This field must contain at least %d words", min)
+		}
+
+		return true, ""
+	}
+}
+
+// MaxWords creates a validation function for a maximum number of words
+func MaxWords(max int) ValidationFunc {
+	return func(field, value string) (bool, string) {
+		if len(strings.Fields(value)) > max {
+			return false, fmt.Sprintf("This field must not exceed %d words", max)
+		}
+
+		return true, ""
+	}
+}
+
+// emailRegex matches a basic email address format.
+var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+
+// Email validates email format
+func Email(field, value string) (bool, string) {
+	if !emailRegex.MatchString(value) {
+		return false, "Please enter a valid email address"
+	}
+
+	return true, ""
+}
+
+// Matches creates a validation function for regex pattern matching.
+// The pattern is compiled once and panics if it is invalid.
+func Matches(pattern string, message string) ValidationFunc {
+	return matchesRegex(regexp.MustCompile(pattern), message)
+}
+
+// MatchesSafe is like Matches but returns an error instead of panicking on an invalid pattern.
+func MatchesSafe(pattern string, message string) (ValidationFunc, error) {
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	return matchesRegex(regex, message), nil
+}
+
+// matchesRegex creates a validation function for a compiled regex
+func matchesRegex(regex *regexp.Regexp, message string) ValidationFunc {
+	return func(field, value string) (bool, string) {
+		if !regex.MatchString(value) {
+			return false, message
+		}
+
+		return true, ""
+	}
+}
+
+// maxCompiledPatterns bounds the number of patterns cached by compilePattern, so
+// that dynamically built patterns cannot grow the cache without limit.
+const maxCompiledPatterns = 256
+
+// compiledPatterns caches the patterns compiled by compilePattern.
+var compiledPatterns = struct {
+	sync.Mutex
+	regexes map[string]*regexp.Regexp
+}{regexes: make(map[string]*regexp.Regexp)}
+
+// compilePattern compiles a regex pattern passed at validation time, caching the
+// result so that frequently used patterns are compiled only once. The cache is
+// emptied when it holds maxCompiledPatterns patterns.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	compiledPatterns.Lock()
+	regex, ok := compiledPatterns.regexes[pattern]
+	compiledPatterns.Unlock()
+	if ok {
+		return regex, nil
+	}
+
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	compiledPatterns.Lock()
+	if len(compiledPatterns.regexes) >= maxCompiledPatterns {
+		clear(compiledPatterns.regexes)
+	}
+	compiledPatterns.regexes[pattern] = regex
+	compiledPatterns.Unlock()
+
+	return regex, nil
+}
+
+// MatchesAny creates a validation function that passes if any of the regex patterns match
+func MatchesAny(patterns []string, message string) ValidationFunc {
+	regexes := make([]*regexp.Regexp, len(patterns))
+	for i, pattern := range patterns {
+		regexes[i] = regexp.MustCompile(pattern)
+	}
+
+	return func(field, value string) (bool, string) {
+		for _, regex := range regexes {
+			if regex.MatchString(value) {
+				return true, ""
+			}
+		}
+
+		return false, message
+	}
+}
+
+// Boolean validates that a value is "true" or "false"
+func Boolean(field, value string) (bool, string) {
+	value = strings.TrimSpace(strings.ToLower(value))
+
+	_, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, "This field must be true or false"
+	}
+
+	return true, ""
+}
+
+// MustConsent validates that a consent field is present and truthy ("true", "1", "on" or "yes").
+// An unchecked checkbox is not submitted at all, so an empty value fails.
+func MustConsent(field, value string) (bool, string) {
+	switch strings.TrimSpace(strings.ToLower(value)) {
+	case "true", "1", "on", "yes":
+		return true, ""
+	}
+
+	return false, "You must provide consent to continue"
+}
+
+// IntRange creates a validation function for integer range.
+func IntRange(min, max int) ValidationFunc {
+	return func(field, value string) (bool, string) {
+		intValue, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return false, "This field must be a valid integer"
+		}
+
+		if intValue < int64(min) || intValue > int64(max) {
+			return false, fmt.Sprintf("This field must be between %d and %d", min, max)
+		}
+
+		return true, ""
+	}
+}
+
+// MinValue creates a validation function for integers of at least min.
+func MinValue(min int64) ValidationFunc {
+	return func(field, value string) (bool, string) {
+		intValue, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return false, "This field must be a valid integer"
+		}
+
+		if intValue < min {
+			return false, fmt.Sprintf("This field must be at least %d", min)
+		}
+
+		return true, ""
+	}
+}
+
+// MaxValue creates a validation function for integers of at most max.
+func MaxValue(max int64) ValidationFunc {
+	return func(field, value string) (bool, string) {
+		intValue, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return false, "This field must be a valid integer"
+		}
+
+		if intValue > max {
+			return false, fmt.Sprintf("This field must not exceed %d", max)
+		}
+
+		return true, ""
+	}
+}
+
+// decimalRegex matches a decimal number with a dot separator and an optional exponent,
+// excluding the hexadecimal, Inf and NaN forms strconv.ParseFloat also accepts.
+var decimalRegex = regexp.MustCompile(`^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)([eE][+-]?[0-9]+)?$`)
+
+// parseDecimal parses a finite decimal number such as "12.5" or "1e3".
+func parseDecimal(value string) (float64, bool) {
+	if !decimalRegex.MatchString(value) {
+		return 0, false
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsInf(number, 0) {
+		return 0, false
+	}
+
+	return number, true
+}
+
+// FloatRange creates a validation function for decimal number range.
+func FloatRange(min, max float64) ValidationFunc {
+	return func(field, value string) (bool, string) {
+		floatValue, ok := parseDecimal(value)
+		if !ok {
+			return false, "This field must be a valid number"
+		}
+
+		if floatValue < min || floatValue > max {
+			return false, fmt.Sprintf("This field must be between %g and %g", min, max)
+		}
+
+		return true, ""
+	}
+}
+
+// GreaterThanValue creates a validation function for integers strictly greater than
+// threshold (e.g. a stored version number). An empty message uses a default.
+func GreaterThanValue(threshold int64, message string) ValidationFunc {
+	if message == "" {
+		message = fmt.Sprintf("Must be greater than %d", threshold)
+	}
+
+	return func(field, value string) (bool, string) {
+		intValue, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return false, "This field must be a valid integer"
+		}
+
+		if intValue <= threshold {
+			return false, message
+		}
+
+		return true, ""
+	}
+}
+
+// GreaterThanFloatValue creates a validation function for numbers strictly greater
+// than threshold. An empty message uses a default.
+func GreaterThanFloatValue(threshold float64, message string) ValidationFunc {
+	if message == "" {
+		message = fmt.Sprintf("Must be greater than %g", threshold)
+	}
+
+	return func(field, value string) (bool, string) {
+		floatValue, ok := parseDecimal(value)
+		if !ok {
+			return false, "This field must be a valid number"
+		}
+
+		if floatValue <= threshold {
+			return false, message
+		}
+
+		return true, ""
+	}
+}
+
+// InStringSlice creates a validation function that checks if a value exists in a slice.
+func InStringSlice(slice []string) ValidationFunc {
+	return func(field, value string) (bool, string) {
+		for _, item := range slice {
+			if item == value {
+				return true, ""
+			}
+		}
+
+		return false, "This value is not in the allowed list"
+	}
+}
+
+// InPathSet creates a validation function for hierarchical values such as
+// "electronics/phones/android" that must be one of the allowed paths.
+func InPathSet(allowed []string, sep string) ValidationFunc {
+	return inPathSet(allowed, sep, false)
+}
+
+// InPathPrefixSet is like InPathSet but also accepts any ancestor of an allowed
+// path, e.g. "electronics" or "electronics/phones".
+func InPathPrefixSet(allowed []string, sep string) ValidationFunc {
+	return inPathSet(allowed, sep, true)
+}
+
+// inPathSet builds the set of accepted paths once, including ancestors if prefixes are allowed.
+func inPathSet(allowed []string, sep string, prefixes bool) ValidationFunc {
+	set := make(map[string]struct{}, len(allowed))
+	for _, path := range allowed {
+		set[path] = struct{}{}
+
+		if prefixes {
+			segments := strings.Split(path, sep)
+			for i := 1; i < len(segments); i++ {
+				set[strings.Join(segments[:i], sep)] = struct{}{}
+			}
+		}
+	}
+
+	return func(field, value string) (bool, string) {
+		if _, ok := set[value]; !ok {
+			return false, "This category is not allowed"
+		}
+
+		return true, ""
+	}
+}
+
+// InSetFunc creates a validation function that checks if a value exists in the set
+// returned by get. The set is fetched on every validation, so it can be refreshed
+// (e.g. reloaded from a database) without rebuilding validators.
+func InSetFunc(get func() map[string]struct{}, message string) ValidationFunc {
+	return func(field, value string) (bool, string) {
+		if _, ok := get()[value]; !ok {
+			return false, message
+		}
+
+		return true, ""
+	}
+}
+
+// InSlice creates a validation function that checks if a value matches one of the
+// typed values, as rendered by toString.
+func InSlice[T comparable](values []T, toString func(T) string, message string) ValidationFunc {
+	return func(field, value string) (bool, string) {
+		for _, item := range values {
+			if toString(item) == value {
+				return true, ""
+			}
+		}
+
+		return false, message
+	}
+}
+
+// UniquePrefixOf creates a validation function that passes if the value is an exact
+// match or an unambiguous prefix of one of the values (e.g. "del" for "delete").
+func UniquePrefixOf(values []string) ValidationFunc {
+	return func(field, value string) (bool, string) {
+		if _, ok := resolvePrefix(values, value); !ok {
+			return false, "Ambiguous or unknown value"
+		}
+
+		return true, ""
+	}
+}
+
+// resolvePrefix returns the value matching prefix exactly, or the only value starting with it.
+func resolvePrefix(values []string, prefix string) (string, bool) {
+	if prefix == "" {
+		return "", false
+	}
+
+	match := ""
+	matches := 0
+	for _, item := range values {
+		if item == prefix {
+			return item, true
+		}
+		if strings.HasPrefix(item, prefix) {
+			match = item
+			matches++
+		}
+	}
+
+	return match, matches == 1
+}
+
+// Custom creates a validation function from a custom check.
+func Custom(check func(string) bool, message string) ValidationFunc {
+	return func(field, value string) (bool, string) {
+		if !check(value) {
+			return false, message
+		}
+		return true, ""
+	}
+}
+
+// EqualsExpected creates a validation function for values that must equal one
+// chosen earlier, e.g. in a previous step of a multi-step form. An empty message
+// uses a default.
+func EqualsExpected(expected string, message string) ValidationFunc {
+	if message == "" {
+		message = "Value does not match the earlier selection"
+	}
+
+	return func(field, value string) (bool, string) {
+		if value != expected {
+			return false, message
+		}
+
+		return true, ""
+	}
+}
+
+// Decodable creates a validation function that fails with message when decode
+// returns an error, e.g. for tokens in an application-specific format.
+func Decodable(decode func(string) error, message string) ValidationFunc {
+	return func(field, value string) (bool, string) {
+		if err := decode(value); err != nil {
+			return false, message
+		}
+
+		return true, ""
+	}
+}
+
+// languageTagRegex matches the well-formed BCP 47 language tag structure:
+// language, extlang, script, region, variants, extensions and private use.
+var languageTagRegex = regexp.MustCompile(`^(?i:[a-z]{2,3}(-[a-z]{3}){0,3}(-[a-z]{4})?(-([a-z]{2}|[0-9]{3}))?(-([a-z0-9]{5,8}|[0-9][a-z0-9]{3}))*(-[0-9a-wyz](-[a-z0-9]{2,8})+)*(-x(-[a-z0-9]{1,8})+)?|x(-[a-z0-9]{1,8})+)$`)
+
+// LanguageTag validates that a value is a well-formed BCP 47 language tag (e.g. "en-US").
+func LanguageTag(field, value string) (bool, string) {
+	if !languageTagRegex.MatchString(value) {
+		return false, "Please enter a valid language tag"
+	}
+
+	return true, ""
+}
+
+// qValueRegex matches an HTTP quality value between 0 and 1 with up to 3 decimals.
+var qValueRegex = regexp.MustCompile(`^(0(\.[0-9]{0,3})?|1(\.0{0,3})?)$`)
+
+// AcceptLanguageList validates an Accept-Language style list such as "en-US,en;q=0.9,fr;q=0.8".
+func AcceptLanguageList(field, value string) (bool, string) {
+	for _, item := range strings.Split(value, ",") {
+		tag, params, hasParams := strings.Cut(strings.TrimSpace(item), ";")
+		tag = strings.TrimSpace(tag)
+
+		if tag != "*" && !languageTagRegex.MatchString(tag) {
+			return false, "Please enter a valid language preference list"
+		}
+
+		if hasParams {
+			q, found := strings.CutPrefix(strings.TrimSpace(params), "q=")
+			if !found || !qValueRegex.MatchString(q) {
+				return false, "Please enter a valid language preference list"
+			}
+		}
+	}
+
+	return true, ""
+}
+
+// Even validates that a value is an even integer.
+func Even(field, value string) (bool, string) {
+	intValue, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return false, "This field must be a valid integer"
+	}
+
+	if intValue%2 != 0 {
+		return false, "This field must be an even number"
+	}
+
+	return true, ""
+}
+
+// Odd validates that a value is an odd integer.
+func Odd(field, value string) (bool, string) {
+	intValue, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return false, "This field must be a valid integer"
+	}
+
+	if intValue%2 == 0 {
+		return false, "This field must be an odd number"
+	}
+
+	return true, ""
+}
+
+// PowerOfTwo validates that a value is a positive integer power of two.
+func PowerOfTwo(field, value string) (bool, string) {
+	intValue, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return false, "This field must be a valid integer"
+	}
+
+	if intValue <= 0 || intValue&(intValue-1) != 0 {
+		return false, "This field must be a power of two"
+	}
+
+	return true, ""
+}
+
+// QueryString validates that a value is a well-formed URL query string.
+func QueryString(field, value string) (bool, string) {
+	if _, err := url.ParseQuery(value); err != nil {
+		return false, "Please enter a valid query string"
+	}
+
+	return true, ""
+}
+
+// NotPwned creates a validation function that rejects values found in a set of
+// commonly breached passwords. Entries in list are expected to be lowercase.
+func NotPwned(list map[string]struct{}) ValidationFunc {
+	return func(field, value string) (bool, string) {
+		if _, found := list[strings.ToLower(value)]; found {
+			return false, "This password is too common; please choose another"
+		}
+
+		return true, ""
+	}
+}
+
+// currencyMinorUnits maps ISO 4217 currency codes to their number of decimal places.
+var currencyMinorUnits = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"AUD": 2, "BRL": 2, "CAD": 2, "CHF": 2, "CNY": 2, "CZK": 2, "DKK": 2, "EUR": 2,
+	"GBP": 2, "HKD": 2, "INR": 2, "MXN": 2, "NOK": 2, "NZD": 2, "PLN": 2, "SEK": 2,
+	"SGD": 2, "TRY": 2, "USD": 2, "ZAR": 2,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// amountRegex matches a plain decimal amount such as "12" or "-12.50".
+var amountRegex = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// MoneyForCurrency creates a validation function that checks an amount uses at most
+// the number of decimal places allowed by the currency held in currencyField.
+func MoneyForCurrency(currencyField string) ContextValidationFunc {
+	return func(v *Validator, field, value string) (bool, string) {
+		currency := strings.ToUpper(strings.TrimSpace(v.GetValue(currencyField)))
+		decimals, ok := currencyMinorUnits[currency]
+		if !ok {
+			return false, fmt.Sprintf("Unsupported currency %q", currency)
+		}
+
+		if !amountRegex.MatchString(value) {
+			return false, "Please enter a valid amount"
+		}
+
+		_, fraction, _ := strings.Cut(value, ".")
+		if len(fraction) > decimals {
+			if decimals == 0 {
+				return false, fmt.Sprintf("Amounts in %s cannot have decimal places", currency)
+			}
+			return false, fmt.Sprintf("Amounts in %s can have at most %d decimal places", currency, decimals)
+		}
+
+		return true, ""
+	}
+}
+
+// PercentEncoded validates that a value is an already percent-encoded URL path segment,
+// i.e. decoding then re-encoding it gives back the same string.
+func PercentEncoded(field, value string) (bool, string) {
+	decoded, err := url.PathUnescape(value)
+	if err != nil || url.PathEscape(decoded) != value {
+		return false, "This field must be URL-encoded"
+	}
+
+	return true, ""
+}
+
+// DataURI validates that a value is a well-formed data URI with a decodable payload.
+func DataURI(field, value string) (bool, string) {
+	if _, ok := parseDataURI(value); !ok {
+		return false, "Please enter a valid data URI"
+	}
+
+	return true, ""
+}
+
+// DataURITypes creates a validation function for data URIs restricted to the given media types.
+func DataURITypes(types ...string) ValidationFunc {
+	return func(field, value string) (bool, string) {
+		mediaType, ok := parseDataURI(value)
+		if !ok {
+			return false, "Please enter a valid data URI"
+		}
+
+		for _, allowed := range types {
+			if strings.EqualFold(allowed, mediaType) {
+				return true, ""
+			}
+		}
+
+		return false, fmt.Sprintf("Invalid data URI type. Allowed: %s", strings.Join(types, ", "))
+	}
+}
+
+// parseDataURI parses a data URI and returns its media type.
+func parseDataURI(value string) (string, bool) {
+	rest, found := strings.CutPrefix(value, "data:")
+	if !found {
+		return "", false
+	}
+
+	header, payload, found := strings.Cut(rest, ",")
+	if !found {
+		return "", false
+	}
+
+	header, isBase64 := strings.CutSuffix(header, ";base64")
+
+	// The media type defaults to text/plain when omitted.
+	mediaType := "text/plain"
+	if header != "" && !strings.HasPrefix(header, ";") {
+		parsed, _, err := mime.ParseMediaType(header)
+		if err != nil {
+			return "", false
+		}
+		mediaType = parsed
+	}
+
+	data, err := url.PathUnescape(payload)
+	if err != nil {
+		return "", false
+	}
+
+	if isBase64 {
+		if _, err := base64.StdEncoding.DecodeString(data); err != nil {
+			return "", false
+		}
+	}
+
+	return mediaType, true
+}
+
+// WithinPercent creates a validation function that checks a number is within pct
+// percent of the number held in otherField.
+func WithinPercent(otherField string, pct float64) ContextValidationFunc {
+	return func(v *Validator, field, value string) (bool, string) {
+		number, ok := parseDecimal(value)
+		if !ok {
+			return false, "This field must be a valid number"
+		}
+
+		reference, ok := parseDecimal(v.GetValue(otherField))
+		if !ok {
+			return false, fmt.Sprintf("Cannot compare with %s: not a valid number", otherField)
+		}
+
+		// A zero reference only tolerates an exact match.
+		if reference == 0 {
+			if number != 0 {
+				return false, fmt.Sprintf("Value must be within %g%% of %s", pct, otherField)
+			}
+			return true, ""
+		}
+
+		if math.Abs(number-reference)/math.Abs(reference)*100 > pct {
+			return false, fmt.Sprintf("Value must be within %g%% of %s", pct, otherField)
+		}
+
+		return true, ""
+	}
+}
+
+// phoneFormatting strips the separators commonly used when writing phone numbers.
+var phoneFormatting = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "")
+
+// nationalPhonePatterns maps ISO 3166 country codes to their national number format.
+var nationalPhonePatterns = map[string]*regexp.Regexp{
+	"US": regexp.MustCompile(`^[2-9][0-9]{2}[2-9][0-9]{6}$`),
+	"CA": regexp.MustCompile(`^[2-9][0-9]{2}[2-9][0-9]{6}$`),
+	"GB": regexp.MustCompile(`^0[1-9][0-9]{8,9}$`),
+	"FR": regexp.MustCompile(`^0[1-9][0-9]{8}$`),
+	"DE": regexp.MustCompile(`^0[1-9][0-9]{5,12}$`),
+	"ES": regexp.MustCompile(`^[6-9][0-9]{8}$`),
+	"IT": regexp.MustCompile(`^[03][0-9]{5,10}$`),
+	"AU": regexp.MustCompile(`^0[2-478][0-9]{8}$`),
+	"IN": regexp.MustCompile(`^[6-9][0-9]{9}$`),
+	"JP": regexp.MustCompile(`^0[1-9][0-9]{8,9}$`),
+	"BR": regexp.MustCompile(`^[1-9]{2}9?[0-9]{8}$`),
+}
+
+// NationalPhone creates a validation function for phone numbers written in the
+// national format of the given country (e.g. "(415) 555-2671" for "US").
+// It panics if the country is not supported.
+func NationalPhone(country string) ValidationFunc {
+	pattern, ok := nationalPhonePatterns[strings.ToUpper(country)]
+	if !ok {
+		panic(fmt.Sprintf("form_validator: unsupported phone country %q", country))
+	}
+
+	return func(field, value string) (bool, string) {
+		if !pattern.MatchString(phoneFormatting.Replace(value)) {
+			return false, "Please enter a valid phone number"
+		}
+
+		return true, ""
+	}
+}
+
+// e164Regex matches an E.164 phone number: a plus sign and 7 to 15 digits.
+var e164Regex = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// countryCallingCodes maps the countries of nationalPhonePatterns to their calling code.
+var countryCallingCodes = map[string]string{
+	"US": "1",
+	"CA": "1",
+	"GB": "44",
+	"FR": "33",
+	"DE": "49",
+	"ES": "34",
+	"IT": "39",
+	"AU": "61",
+	"IN": "91",
+	"JP": "81",
+	"BR": "55",
+}
+
+// Phone validates an international phone number in E.164 format (e.g. "+14155552671").
+// Spaces, dashes, dots and parentheses are ignored.
+func Phone(field, value string) (bool, string) {
+	if !e164Regex.MatchString(phoneFormatting.Replace(value)) {
+		return false, "Please enter a valid phone number"
+	}
+
+	return true, ""
+}
+
+// PhoneRegion creates a validation function for phone numbers of a country, written
+// either in E.164 format with the country's calling code or in its national format.
+// It panics if the region is not supported.
+func PhoneRegion(region string) ValidationFunc {
+	region = strings.ToUpper(region)
+	national := NationalPhone(region)
+	code := countryCallingCodes[region]
+
+	return func(field, value string) (bool, string) {
+		number := phoneFormatting.Replace(value)
+		if !strings.HasPrefix(number, "+") {
+			return national(field, value)
+		}
+
+		if !e164Regex.MatchString(number) || !strings.HasPrefix(number[1:], code) {
+			return false, "Please enter a valid phone number"
+		}
+
+		return true, ""
+	}
+}
+
+// hostnameRegex matches an RFC 1123 hostname label by label.
+var hostnameRegex = regexp.MustCompile(`^(?i:[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)(\.(?i:[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?))*$`)
+
+// isHostname reports whether value is a valid RFC 1123 hostname.
+func isHostname(value string) bool {
+	return len(value) <= 253 && hostnameRegex.MatchString(value)
+}
+
+// HostnameOrIP validates that a value is an RFC 1123 hostname or an IPv4/IPv6 address.
+func HostnameOrIP(field, value string) (bool, string) {
+	if net.ParseIP(value) == nil && !isHostname(value) {
+		return false, "Please enter a valid hostname or IP address"
+	}
+
+	return true, ""
+}
+
+// HostPort validates that a value is a host (hostname or IP) and a port in 1-65535.
+func HostPort(field, value string) (bool, string) {
+	host, port, err := net.SplitHostPort(value)
+	if err != nil {
+		return false, "Please enter a valid host:port"
+	}
+
+	if net.ParseIP(host) == nil && !isHostname(host) {
+		return false, "Please enter a valid host:port"
+	}
+
+	if ok, _ := Port(field, port); !ok {
+		return false, "Please enter a valid host:port"
+	}
+
+	return true, ""
+}
+
+// Port validates that a value is a port number between 1 and 65535.
+func Port(field, value string) (bool, string) {
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		return false, "Please enter a valid port number (1-65535)"
+	}
+
+	return true, ""
+}
+
+// PortAllowZero validates that a value is a port number between 0 and 65535,
+// where 0 usually means "pick any available port".
+func PortAllowZero(field, value string) (bool, string) {
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 0 || port > 65535 {
+		return false, "Please enter a valid port number (0-65535)"
+	}
+
+	return true, ""
+}
+
+// Date creates a validation function for dates in the given time.Parse layout.
+// Impossible dates such as "2023-02-30" are rejected.
+func Date(layout string) ValidationFunc {
+	return func(field, value string) (bool, string) {
+		if _, err := time.Parse(layout, value); err != nil {
+			return false, "Please enter a valid date"
+		}
+
+		return true, ""
+	}
+}
+
+// isoDateLayout is the layout of an ISO 8601 calendar date.
+const isoDateLayout = "2006-01-02"
+
+// DateISO validates that a value is an ISO 8601 date such as "2006-01-02".
+func DateISO(field, value string) (bool, string) {
+	return Date(isoDateLayout)(field, value)
+}
+
+// DateBefore creates a validation function for dates strictly before max
+// (e.g. time.Now() for a birthdate).
+func DateBefore(layout string, max time.Time) ValidationFunc {
+	return func(field, value string) (bool, string) {
+		date, err := time.Parse(layout, value)
+		if err != nil {
+			return false, "Please enter a valid date"
+		}
+
+		if !date.Before(max) {
+			return false, fmt.Sprintf("Date must be before %s", max.Format(layout))
+		}
+
+		return true, ""
+	}
+}
+
+// DateAfter creates a validation function for dates strictly after min.
+func DateAfter(layout string, min time.Time) ValidationFunc {
+	return func(field, value string) (bool, string) {
+		date, err := time.Parse(layout, value)
+		if err != nil {
+			return false, "Please enter a valid date"
+		}
+
+		if !date.After(min) {
+			return false, fmt.Sprintf("Date must be after %s", min.Format(layout))
+		}
+
+		return true, ""
+	}
+}
+
+// NotInDates creates a validation function that rejects dates falling on the same
+// calendar day as any of the blocked dates, ignoring the time of day.
+func NotInDates(blocked []time.Time, layout string) ValidationFunc {
+	return func(field, value string) (bool, string) {
+		date, err := time.Parse(layout, value)
+		if err != nil {
+			return false, "Please enter a valid date"
+		}
+
+		year, month, day := date.Date()
+		for _, b := range blocked {
+			if by, bm, bd := b.Date(); by == year && bm == month && bd == day {
+				return false, "This date is not available"
+			}
+		}
+
+		return true, ""
+	}
+}
+
+// MaxDateSpan creates a validation function that checks the dates held in startField
+// and endField are in order and at most maxDays apart.
+func MaxDateSpan(startField, endField, layout string, maxDays int) ContextValidationFunc {
+	return func(v *Validator, field, value string) (bool, string) {
+		start, err := time.Parse(layout, v.GetValue(startField))
+		if err != nil {
+			return false, "Please enter a valid start date"
+		}
+
+		end, err := time.Parse(layout, v.GetValue(endField))
+		if err != nil {
+			return false, "Please enter a valid end date"
+		}
+
+		if end.Before(start) {
+			return false, "End date must not be before start date"
+		}
+
+		if end.Sub(start) > time.Duration(maxDays)*24*time.Hour {
+			return false, fmt.Sprintf("Date range cannot exceed %d days", maxDays)
+		}
+
+		return true, ""
+	}
+}
+
+// BalancedBrackets validates that (), [] and {} are balanced and properly nested.
+func BalancedBrackets(field, value string) (bool, string) {
+	pairs := map[rune]rune{')': '(', ']': '[', '}': '{'}
+	stack := make([]rune, 0)
+
+	for _, r := range value {
+		switch r {
+		case '(', '[', '{':
+			stack = append(stack, r)
+		case ')', ']', '}':
+			if len(stack) == 0 || stack[len(stack)-1] != pairs[r] {
+				return false, "Unbalanced brackets"
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	if len(stack) != 0 {
+		return false, "Unbalanced brackets"
+	}
+
+	return true, ""
+}
+
+// FiniteNumber validates that a value is a number other than Inf or NaN.
+func FiniteNumber(field, value string) (bool, string) {
+	_, ok := parseDecimal(value)
+	if !ok {
+		return false, "Please enter a finite number"
+	}
+
+	return true, ""
+}
+
+// NotContainsField creates a validation function that fails when the value contains
+// the value of otherField, ignoring case (e.g. a password containing the username).
+func NotContainsField(otherField string) ContextValidationFunc {
+	return func(v *Validator, field, value string) (bool, string) {
+		other := strings.TrimSpace(v.GetValue(otherField))
+		if other == "" {
+			return true, ""
+		}
+
+		if strings.Contains(strings.ToLower(value), strings.ToLower(other)) {
+			return false, "Password must not contain your username/email"
+		}
+
+		return true, ""
+	}
+}
+
+// objectIDRegex matches a MongoDB ObjectID: 24 hexadecimal characters.
+var objectIDRegex = regexp.MustCompile(`^[0-9a-fA-F]{24}$`)
+
+// ObjectID validates that a value is a MongoDB ObjectID.
+func ObjectID(field, value string) (bool, string) {
+	if !objectIDRegex.MatchString(value) {
+		return false, "Please enter a valid ObjectID"
+	}
+
+	return true, ""
+}
+
+// MatchesHash creates a validation function that checks a value against a stored hash
+// using the provided compare function (e.g. a wrapper around bcrypt.CompareHashAndPassword).
+func MatchesHash(hashedRef string, compare func(hash, plain string) bool) ValidationFunc {
+	return func(field, value string) (bool, string) {
+		if !compare(hashedRef, value) {
+			return false, "Current password is incorrect"
+		}
+
+		return true, ""
+	}
+}
+
+// UnixTimestamp creates a validation function for Unix timestamps (in seconds)
+// falling within [min, max].
+func UnixTimestamp(min, max time.Time) ValidationFunc {
+	return func(field, value string) (bool, string) {
+		seconds, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return false, "Timestamp must be a whole number of seconds"
+		}
+
+		timestamp := time.Unix(seconds, 0)
+		if timestamp.Before(min) || timestamp.After(max) {
+			return false, "Timestamp is out of the allowed range"
+		}
+
+		return true, ""
+	}
+}
+
+// RecentUnixTimestamp creates a validation function for Unix timestamps (in seconds)
+// within tolerance of the current time, e.g. to reject replayed signed requests.
+func RecentUnixTimestamp(tolerance time.Duration) ValidationFunc {
+	return func(field, value string) (bool, string) {
+		seconds, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return false, "Timestamp must be a whole number of seconds"
+		}
+
+		now := time.Now()
+		timestamp := time.Unix(seconds, 0)
+		if timestamp.Before(now.Add(-tolerance)) || timestamp.After(now.Add(tolerance)) {
+			return false, "Request timestamp is outside the allowed window"
+		}
+
+		return true, ""
+	}
+}
+
+// Hashtag and mention formats: a leading symbol followed by letters, digits or underscores.
+var (
+	hashtagRegex = regexp.MustCompile(`^#[\p{L}\p{N}_]{1,100}$`)
+	mentionRegex = regexp.MustCompile(`^@[\p{L}\p{N}_]{1,30}$`)
+)
+
+// Hashtag validates that a value is a hashtag such as "#golang" (up to 100 characters after "#").
+func Hashtag(field, value string) (bool, string) {
+	if !hashtagRegex.MatchString(value) {
+		return false, "Please enter a valid hashtag (e.g. #golang)"
+	}
+
+	return true, ""
+}
+
+// Mention validates that a value is a mention such as "@gopher" (up to 30 characters after "@").
+func Mention(field, value string) (bool, string) {
+	if !mentionRegex.MatchString(value) {
+		return false, "Please enter a valid mention (e.g. @gopher)"
+	}
+
+	return true, ""
+}
+
+// MinCharClasses creates a validation function requiring characters from at least n
+// of the lowercase, uppercase, digit and symbol classes.
+func MinCharClasses(n int) ValidationFunc {
+	return func(field, value string) (bool, string) {
+		var lower, upper, digit, symbol bool
+		for _, r := range value {
+			switch {
+			case unicode.IsLower(r):
+				lower = true
+			case unicode.IsUpper(r):
+				upper = true
+			case unicode.IsDigit(r):
+				digit = true
+			case !unicode.IsSpace(r):
+				symbol = true
+			}
+		}
+
+		classes := 0
+		for _, present := range []bool{lower, upper, digit, symbol} {
+			if present {
+				classes++
+			}
+		}
+
+		if classes < n {
+			return false, fmt.Sprintf("Please use at least %d different character types", n)
+		}
+
+		return true, ""
+	}
+}
+
+// IntBetweenFields creates a validation function that checks an integer lies between
+// the integers held in minField and maxField (inclusive).
+func IntBetweenFields(minField, maxField string) ContextValidationFunc {
+	return func(v *Validator, field, value string) (bool, string) {
+		min, minErr := strconv.ParseInt(v.GetValue(minField), 10, 64)
+		max, maxErr := strconv.ParseInt(v.GetValue(maxField), 10, 64)
+		if minErr != nil || maxErr != nil {
+			return false, fmt.Sprintf("Cannot validate range: %s and %s must be valid integers", minField, maxField)
+		}
+
+		intValue, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return false, "This field must be a valid integer"
+		}
+
+		if intValue < min || intValue > max {
+			return false, fmt.Sprintf("This field must be between %d and %d", min, max)
+		}
+
+		return true, ""
+	}
+}
+
+// Captcha creates a validation function that checks a CAPTCHA token with the given
+// verifier. The client IP is passed along when the validator was created by NewHTTP.
+func Captcha(verify func(token, remoteIP string) (bool, error)) ContextValidationFunc {
+	return func(v *Validator, field, value string) (bool, string) {
+		if strings.TrimSpace(value) == "" {
+			return false, "CAPTCHA verification failed"
+		}
+
+		remoteIP := ""
+		if v.remoteIP != nil {
+			remoteIP = v.remoteIP()
+		}
+
+		ok, err := verify(value, remoteIP)
+		if err != nil {
+			return false, "CAPTCHA could not be verified, please try again"
+		}
+		if !ok {
+			return false, "CAPTCHA verification failed"
+		}
+
+		return true, ""
+	}
+}
+
+// urlRegex matches explicit http(s) URLs, "www." hosts and bare domains ending in
+// one of the TLDs most used for links (e.g. "example.com/path"). Bare domains are
+// limited to known TLDs so that text such as "Mr.Smith" or "it.Really" is allowed.
+var urlRegex = regexp.MustCompile(`(?i)(https?://|www\.)\S+|\b[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)*\.(com|net|org|info|biz|io|co|me|app|dev|xyz|top|online|site|shop|club|link|ly|gg|tk|ru|cn|uk|de|fr|us)\b(/\S*)?`)
+
+// NoURLs validates that a value does not contain links.
+func NoURLs(field, value string) (bool, string) {
+	if urlRegex.MatchString(value) {
+		return false, "Links are not allowed in this field"
+	}
+
+	return true, ""
+}
+
+// RoundsToCents validates that an amount has at most 2 decimal places. The check is
+// done on the string, so values are never silently rounded.
+func RoundsToCents(field, value string) (bool, string) {
+	if !amountRegex.MatchString(value) {
+		return false, "Please enter a valid amount"
+	}
+
+	if _, fraction, _ := strings.Cut(value, "."); len(fraction) > 2 {
+		return false, "Amount can have at most 2 decimal places"
+	}
+
+	return true, ""
+}
+
+// JSONPointer validates that a value is an RFC 6901 JSON pointer such as "/foo/0/bar".
+// The empty string is valid and refers to the whole document.
+func JSONPointer(field, value string) (bool, string) {
+	if value != "" && !strings.HasPrefix(value, "/") {
+		return false, "Please enter a valid JSON pointer"
+	}
+
+	for i := 0; i < len(value); i++ {
+		if value[i] == '~' && (i+1 == len(value) || (value[i+1] != '0' && value[i+1] != '1')) {
+			return false, "Please enter a valid JSON pointer"
+		}
+	}
+
+	return true, ""
+}
+
+// reservedPrefixes lists the IANA special-purpose and non-unicast address ranges
+// that are not reachable as public hosts. IPv4-mapped IPv6 addresses are checked
+// against the IPv4 ranges.
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // "this" network.
+	netip.MustParsePrefix("10.0.0.0/8"),      // private.
+	netip.MustParsePrefix("100.64.0.0/10"),   // carrier-grade NAT.
+	netip.MustParsePrefix("127.0.0.0/8"),     // loopback.
+	netip.MustParsePrefix("169.254.0.0/16"),  // link-local.
+	netip.MustParsePrefix("172.16.0.0/12"),   // private.
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocol assignments.
+	netip.MustParsePrefix("192.0.2.0/24"),    // documentation.
+	netip.MustParsePrefix("192.88.99.0/24"),  // 6to4 relay anycast.
+	netip.MustParsePrefix("192.168.0.0/16"),  // private.
+	netip.MustParsePrefix("198.18.0.0/15"),   // benchmarking.
+	netip.MustParsePrefix("198.51.100.0/24"), // documentation.
+	netip.MustParsePrefix("203.0.113.0/24"),  // documentation.
+	netip.MustParsePrefix("224.0.0.0/4"),     // multicast.
+	netip.MustParsePrefix("240.0.0.0/4"),     // reserved and broadcast.
+	netip.MustParsePrefix("::/128"),          // unspecified.
+	netip.MustParsePrefix("::1/128"),         // loopback.
+	netip.MustParsePrefix("64:ff9b::/96"),    // NAT64.
+	netip.MustParsePrefix("64:ff9b:1::/48"),  // local NAT64.
+	netip.MustParsePrefix("100::/64"),        // discard-only.
+	netip.MustParsePrefix("2001::/23"),       // IETF protocol assignments, including Teredo.
+	netip.MustParsePrefix("2001:db8::/32"),   // documentation.
+	netip.MustParsePrefix("2002::/16"),       // 6to4.
+	netip.MustParsePrefix("fc00::/7"),        // unique local.
+	netip.MustParsePrefix("fe80::/10"),       // link-local.
+	netip.MustParsePrefix("ff00::/8"),        // multicast.
+}
+
+// isPublicIP reports whether an IP address is routable on the public internet.
+func isPublicIP(ip net.IP) bool {
+	addr, ok := netip.AddrFromSlice(ip)
+	if !ok {
+		return false
+	}
+	addr = addr.Unmap()
+
+	for _, prefix := range reservedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// PublicIP validates that a value is an IP address outside the private, loopback,
+// link-local, multicast and other reserved ranges.
+func PublicIP(field, value string) (bool, string) {
+	ip := net.ParseIP(value)
+	if ip == nil || !isPublicIP(ip) {
+		return false, "Please enter a public IP address"
+	}
+
+	return true, ""
+}
+
+// Resolver looks up the IP addresses of a host. It is implemented by *net.Resolver.
+type Resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// publicURLTimeout bounds the DNS lookup performed by PublicURL.
+const publicURLTimeout = 5 * time.Second
+
+// PublicURL validates that a value is an http(s) URL whose host only resolves to
+// public IP addresses, using the default resolver with a 5 second timeout. See
+// PublicURLWith.
+func PublicURL(field, value string) (bool, string) {
+	return PublicURLWith(net.DefaultResolver, publicURLTimeout)(field, value)
+}
+
+// PublicURLWith creates a validation function for http(s) URLs whose host only
+// resolves to public IP addresses, looking hosts up with resolver within timeout.
+// Note that the host can resolve differently when it is later fetched (DNS
+// rebinding): this check cannot replace validating the address at dial time,
+// e.g. in a net.Dialer Control function.
+func PublicURLWith(resolver Resolver, timeout time.Duration) ValidationFunc {
 	return func(field, value string) (bool, string) {
-		// Add proper int conversion and range check here
+		u, err := url.Parse(value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+			return false, "Please enter a public URL"
+		}
+
+		ips := []net.IP{net.ParseIP(u.Hostname())}
+		if ips[0] == nil {
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			addrs, err := resolver.LookupIPAddr(ctx, u.Hostname())
+			if err != nil || len(addrs) == 0 {
+				return false, "Please enter a public URL"
+			}
+
+			ips = ips[:0]
+			for _, addr := range addrs {
+				ips = append(ips, addr.IP)
+			}
+		}
+
+		for _, ip := range ips {
+			if !isPublicIP(ip) {
+				return false, "Please enter a public URL"
+			}
+		}
+
 		return true, ""
 	}
 }
 
-// InStringSlice creates a validation function that checks if a value exists in a slice.
-func InStringSlice(slice []string) ValidationFunc {
+// DurationBetween creates a validation function for Go durations (e.g. "90s", "1h30m")
+// within [min, max]. Negative durations are rejected unless min itself is negative.
+func DurationBetween(min, max time.Duration) ValidationFunc {
 	return func(field, value string) (bool, string) {
-		for _, item := range slice {
-			if item == value {
-				return true, ""
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return false, "Please enter a valid duration (e.g. 30m)"
+		}
+
+		if duration < 0 && min >= 0 {
+			return false, "Duration must not be negative"
+		}
+
+		if duration < min || duration > max {
+			return false, fmt.Sprintf("Duration must be between %s and %s", min, max)
+		}
+
+		return true, ""
+	}
+}
+
+// bearerTokenRegex matches an RFC 6750 Bearer credential.
+var bearerTokenRegex = regexp.MustCompile(`^Bearer [A-Za-z0-9\-._~+/]+=*$`)
+
+// BearerToken validates that a value is an Authorization header value such as "Bearer abc123".
+func BearerToken(field, value string) (bool, string) {
+	if !bearerTokenRegex.MatchString(value) {
+		return false, "Please enter a valid Bearer token"
+	}
+
+	return true, ""
+}
+
+// HTTPHeaderValue validates that a value can be sent as an HTTP header value,
+// i.e. it contains no control characters other than horizontal tabs.
+func HTTPHeaderValue(field, value string) (bool, string) {
+	for _, r := range value {
+		if (r < 0x20 && r != '\t') || r == 0x7F {
+			return false, "This field contains characters not allowed in an HTTP header"
+		}
+	}
+
+	return true, ""
+}
+
+// MaxPercentOf creates a validation function that checks a number does not exceed
+// pct percent of reference (e.g. a bid of at most 120% of the list price).
+func MaxPercentOf(reference float64, pct float64) ValidationFunc {
+	return func(field, value string) (bool, string) {
+		number, ok := parseDecimal(value)
+		if !ok {
+			return false, "This field must be a valid number"
+		}
+
+		if number > reference*pct/100 {
+			return false, fmt.Sprintf("Value cannot exceed %g%% of %g", pct, reference)
+		}
+
+		return true, ""
+	}
+}
+
+// SafeRedirectPath validates that a value is a relative path on the same site, guarding
+// against open redirects. Protocol-relative values such as "//evil.com" are rejected,
+// as are backslashes, which browsers treat like forward slashes.
+func SafeRedirectPath(field, value string) (bool, string) {
+	if !strings.HasPrefix(value, "/") || strings.HasPrefix(value, "//") || strings.ContainsAny(value, "\\\r\n\t") {
+		return false, "Invalid redirect target"
+	}
+
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme != "" || u.Host != "" {
+		return false, "Invalid redirect target"
+	}
+
+	return true, ""
+}
+
+// RoutingNumber validates a 9-digit US bank routing number using the ABA checksum.
+func RoutingNumber(field, value string) (bool, string) {
+	if len(value) != 9 {
+		return false, "Please enter a valid routing number"
+	}
+
+	weights := [3]int{3, 7, 1}
+	sum := 0
+	for i, r := range value {
+		if r < '0' || r > '9' {
+			return false, "Please enter a valid routing number"
+		}
+		sum += int(r-'0') * weights[i%3]
+	}
+
+	if sum%10 != 0 {
+		return false, "Please enter a valid routing number"
+	}
+
+	return true, ""
+}
+
+// pathParamRegex matches a path template parameter name.
+var pathParamRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// PathTemplate validates an OpenAPI-style path template such as "/users/{id}/posts/{postId}".
+func PathTemplate(field, value string) (bool, string) {
+	if value == "/" {
+		return true, ""
+	}
+	if !strings.HasPrefix(value, "/") {
+		return false, "Please enter a valid path template"
+	}
+
+	params := make(map[string]bool)
+	for _, segment := range strings.Split(value[1:], "/") {
+		if segment == "" {
+			return false, "Please enter a valid path template"
+		}
+
+		for segment != "" {
+			open := strings.IndexAny(segment, "{}")
+			if open == -1 {
+				break
+			}
+			if segment[open] == '}' {
+				return false, "Please enter a valid path template"
+			}
+
+			name, rest, found := strings.Cut(segment[open+1:], "}")
+			if !found || !pathParamRegex.MatchString(name) || params[name] {
+				return false, "Please enter a valid path template"
 			}
+
+			params[name] = true
+			segment = rest
 		}
+	}
 
-		return false, "This value is not in the allowed list"
+	return true, ""
+}
+
+// FitsCharset creates a validation function checking that a value can be encoded in
+// the named charset (e.g. "latin1", "shift_jis") and takes at most maxBytes once encoded.
+// Names are resolved with the IANA character set registry, so "latin1" is the true
+// ISO-8859-1 rather than the windows-1252 superset browsers map it to.
+func FitsCharset(charset string, maxBytes int) ValidationFunc {
+	encoding, err := ianaindex.IANA.Encoding(charset)
+
+	return func(field, value string) (bool, string) {
+		// Known charsets without an implementation are returned as a nil encoding.
+		if err != nil || encoding == nil {
+			return false, fmt.Sprintf("Unsupported character set %q", charset)
+		}
+
+		encoded, encodeErr := encoding.NewEncoder().String(value)
+		if encodeErr != nil {
+			return false, "This field contains characters that cannot be stored"
+		}
+
+		if len(encoded) > maxBytes {
+			return false, fmt.Sprintf("This field must not exceed %d bytes", maxBytes)
+		}
+
+		return true, ""
 	}
 }
 
-// Custom creates a validation function from a custom check.
-func Custom(check func(string) bool, message string) ValidationFunc {
+// NumberWithUnit creates a validation function for numbers followed by a required
+// unit suffix, such as "4000K" or "250ms", within [min, max].
+func NumberWithUnit(unit string, min, max float64) ValidationFunc {
 	return func(field, value string) (bool, string) {
-		if !check(value) {
+		message := fmt.Sprintf("Please enter a value between %g%s and %g%s", min, unit, max, unit)
+
+		number, found := strings.CutSuffix(strings.TrimSpace(value), unit)
+		if !found {
 			return false, message
 		}
+
+		parsed, ok := parseDecimal(strings.TrimSpace(number))
+		if !ok || parsed < min || parsed > max {
+			return false, message
+		}
+
+		return true, ""
+	}
+}
+
+// uuidRegex matches the canonical 8-4-4-4-12 hexadecimal UUID form.
+var uuidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// UUID validates that a value is a UUID in canonical form. Braced and URN forms are rejected.
+func UUID(field, value string) (bool, string) {
+	if !uuidRegex.MatchString(value) {
+		return false, "Please enter a valid UUID"
+	}
+
+	return true, ""
+}
+
+// UUIDVersion creates a validation function for canonical UUIDs of the given version.
+func UUIDVersion(version int) ValidationFunc {
+	return func(field, value string) (bool, string) {
+		if !uuidRegex.MatchString(value) {
+			return false, "Please enter a valid UUID"
+		}
+
+		// The version is the first hex digit of the third group.
+		if nibble, _ := strconv.ParseInt(value[14:15], 16, 64); int(nibble) != version {
+			return false, "Please enter a valid UUID"
+		}
+
+		return true, ""
+	}
+}
+
+// NFC validates that a value is in Unicode Normalization Form C.
+func NFC(field, value string) (bool, string) {
+	if norm.NFC.String(value) != value {
+		return false, "This field contains non-normalized characters"
+	}
+
+	return true, ""
+}
+
+// SKUMask creates a validation function for codes following a mask such as
+// "AAA-999-X", where A is a letter, 9 a digit, X a letter or digit, and any
+// other character must appear as is.
+func SKUMask(mask string) ValidationFunc {
+	pattern := []rune(mask)
+
+	return func(field, value string) (bool, string) {
+		runes := []rune(value)
+		if len(runes) != len(pattern) {
+			return false, "Please enter a valid SKU"
+		}
+
+		for i, r := range runes {
+			isLetter := (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z')
+			isDigit := r >= '0' && r <= '9'
+
+			var ok bool
+			switch pattern[i] {
+			case 'A':
+				ok = isLetter
+			case '9':
+				ok = isDigit
+			case 'X':
+				ok = isLetter || isDigit
+			default:
+				ok = r == pattern[i]
+			}
+
+			if !ok {
+				return false, "Please enter a valid SKU"
+			}
+		}
+
+		return true, ""
+	}
+}
+
+// ethAddressRegex matches a 0x-prefixed 20-byte hex address in any case.
+var ethAddressRegex = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+
+// EthAddress validates an Ethereum address. Mixed-case addresses must carry a
+// valid EIP-55 checksum; all-lowercase and all-uppercase addresses have none.
+func EthAddress(field, value string) (bool, string) {
+	if !ethAddressRegex.MatchString(value) {
+		return false, "Please enter a valid Ethereum address"
+	}
+
+	address := value[2:]
+	lower := strings.ToLower(address)
+	if address == lower || address == strings.ToUpper(address) {
 		return true, ""
 	}
+
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write([]byte(lower))
+	digest := hash.Sum(nil)
+
+	for i, c := range address {
+		if c < 'A' || (c > 'F' && c < 'a') {
+			continue
+		}
+
+		nibble := digest[i/2] >> 4
+		if i%2 == 1 {
+			nibble = digest[i/2] & 0x0f
+		}
+
+		if (nibble >= 8) != (c >= 'A' && c <= 'F') {
+			return false, "Please enter a valid Ethereum address"
+		}
+	}
+
+	return true, ""
+}
+
+// Alpha validates that a value only contains letters, in any script.
+func Alpha(field, value string) (bool, string) {
+	for _, r := range value {
+		if !unicode.IsLetter(r) {
+			return false, "This field may only contain letters"
+		}
+	}
+
+	return true, ""
+}
+
+// Alphanumeric validates that a value only contains letters and digits, in any script.
+func Alphanumeric(field, value string) (bool, string) {
+	for _, r := range value {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return false, "This field may only contain letters and digits"
+		}
+	}
+
+	return true, ""
+}
+
+// Numeric validates that a value only contains digits, in any script.
+func Numeric(field, value string) (bool, string) {
+	for _, r := range value {
+		if !unicode.IsDigit(r) {
+			return false, "This field may only contain digits"
+		}
+	}
+
+	return true, ""
+}
+
+// slugRegex matches lowercase words of letters and digits joined by single hyphens.
+var slugRegex = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Slug validates a URL-safe identifier such as "my-post-123".
+func Slug(field, value string) (bool, string) {
+	if !slugRegex.MatchString(value) {
+		return false, "This field must be a valid slug"
+	}
+
+	return true, ""
 }
 
 // HTTPValidator extends Validator to work with http.Request.
 type HTTPValidator struct {
 	*Validator
 	request *http.Request
+
+	// TrustProxy makes RemoteIP honor the X-Forwarded-For and X-Real-IP headers.
+	// Only enable it behind a proxy that sets them, as clients can forge them.
+	TrustProxy bool
+
+	// TrustedHops is the number of trusted proxies in front of the application,
+	// each appending to X-Forwarded-For. Zero means a single proxy.
+	TrustedHops int
+}
+
+// FormErrorKey is the Errors key used for problems with the form as a whole.
+const FormErrorKey = "_form"
+
+// HTTPOptions configures how NewHTTPWithOptions loads a request.
+type HTTPOptions struct {
+	MaxFields    int      // maximum number of distinct fields (values and files), 0 for no limit.
+	MaxValues    int      // maximum number of values across all fields, 0 for 10 per allowed field.
+	AutoTrim     bool     // trim the loaded values, see Validator.AutoTrim.
+	AutoTrimSkip []string // fields left untrimmed, see Validator.AutoTrimSkip.
 }
 
 // NewHTTP creates a new HTTP validator.
 func NewHTTP(r *http.Request) *HTTPValidator {
+	return NewHTTPWithOptions(r, HTTPOptions{})
+}
+
+// maxFormBodySize mirrors the limit net/http applies to url-encoded request bodies.
+const maxFormBodySize = 10 << 20
+
+// maxValuesPerField sets the default MaxValues from MaxFields.
+const maxValuesPerField = 10
+
+// NewHTTPWithOptions creates a new HTTP validator with the given options.
+// When the request has more than MaxFields fields or MaxValues values, none of
+// them are loaded and an error is recorded under FormErrorKey. For url-encoded
+// forms the values are counted before the form is built; multipart forms are only
+// counted once parsed, so callers should also bound the request body with
+// http.MaxBytesReader.
+func NewHTTPWithOptions(r *http.Request, options HTTPOptions) *HTTPValidator {
 	v := &HTTPValidator{
 		Validator: New(),
 		request:   r,
 	}
+	v.Validator.remoteIP = v.RemoteIP
+	v.AutoTrim = options.AutoTrim
+	v.AutoTrimSkip = options.AutoTrimSkip
+
+	maxValues := options.MaxValues
+	if maxValues == 0 {
+		maxValues = options.MaxFields * maxValuesPerField
+	}
+
+	if options.MaxFields > 0 || maxValues > 0 {
+		if ok, msg := checkFormLimits(r, options.MaxFields, maxValues); !ok {
+			v.addError(FormErrorKey, msg)
+			return v
+		}
+	}
 
 	// Check if it's a multipart form.
+	var files map[string][]*multipart.FileHeader
 	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
 		err := r.ParseMultipartForm(32 << 20) // 32MB max memory.
-		if err == nil {
-			// Load files
-			if r.MultipartForm != nil && r.MultipartForm.File != nil {
-				for field, files := range r.MultipartForm.File {
-					if len(files) > 0 {
-						v.SetFile(field, files[0])
-					}
-				}
-			}
+		if err == nil && r.MultipartForm != nil {
+			files = r.MultipartForm.File
 		}
 	}
 
 	// Parse regular form values.
 	r.ParseForm()
+
+	values := 0
+	for _, fieldValues := range r.Form {
+		values += len(fieldValues)
+	}
+	for _, fieldFiles := range files {
+		values += len(fieldFiles)
+	}
+
+	if ok, msg := formLimits(len(r.Form)+len(files), values, options.MaxFields, maxValues); !ok {
+		v.addError(FormErrorKey, msg)
+		return v
+	}
+
+	// Load files
+	for field, fieldFiles := range files {
+		if len(fieldFiles) > 0 {
+			v.SetFiles(field, fieldFiles)
+		}
+	}
+
 	for key, values := range r.Form {
 		if len(values) > 0 {
-			v.SetValue(key, values[0])
+			v.SetValues(key, values)
 		}
 	}
 
 	return v
 }
+
+// checkFormLimits counts the fields and values of the query string and url-encoded
+// body of a request, stopping as soon as a limit is exceeded. When the body is
+// within the limits, it is parsed into r.PostForm so that ParseForm does not read
+// it again.
+func checkFormLimits(r *http.Request, maxFields, maxValues int) (bool, string) {
+	keys := make(map[string]struct{})
+	values := 0
+	count := func(query string) (bool, string) {
+		for query != "" {
+			var pair string
+			pair, query, _ = strings.Cut(query, "&")
+			key, _, _ := strings.Cut(pair, "=")
+			if key == "" {
+				continue
+			}
+			if unescaped, err := url.QueryUnescape(key); err == nil {
+				key = unescaped
+			}
+
+			keys[key] = struct{}{}
+			values++
+			if ok, msg := formLimits(len(keys), values, maxFields, maxValues); !ok {
+				return false, msg
+			}
+		}
+
+		return true, ""
+	}
+
+	if ok, msg := count(r.URL.RawQuery); !ok {
+		return false, msg
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if r.Body == nil || mediaType != "application/x-www-form-urlencoded" ||
+		(r.Method != http.MethodPost && r.Method != http.MethodPut && r.Method != http.MethodPatch) {
+		return true, ""
+	}
+
+	var body strings.Builder
+	if _, err := io.Copy(&body, http.MaxBytesReader(nil, r.Body, maxFormBodySize)); err != nil {
+		return false, "Form could not be read"
+	}
+
+	if ok, msg := count(body.String()); !ok {
+		return false, msg
+	}
+
+	r.PostForm, _ = url.ParseQuery(body.String())
+	return true, ""
+}
+
+// formLimits checks field and value counts against their limits, 0 meaning no limit.
+func formLimits(fields, values, maxFields, maxValues int) (bool, string) {
+	if maxFields > 0 && fields > maxFields {
+		return false, fmt.Sprintf("Form exceeds maximum of %d fields", maxFields)
+	}
+
+	if maxValues > 0 && values > maxValues {
+		return false, fmt.Sprintf("Form exceeds maximum of %d values", maxValues)
+	}
+
+	return true, ""
+}
+
+// RemoteIP returns the IP address of the client that sent the request.
+// Forwarding headers are ignored unless TrustProxy is set. Clients can send their
+// own X-Forwarded-For entries, so only the entry appended by the outermost trusted
+// proxy (TrustedHops from the right) is used.
+func (hv *HTTPValidator) RemoteIP() string {
+	if hv.TrustProxy {
+		hops := hv.TrustedHops
+		if hops < 1 {
+			hops = 1
+		}
+
+		var entries []string
+		for _, header := range hv.request.Header.Values("X-Forwarded-For") {
+			entries = append(entries, strings.Split(header, ",")...)
+		}
+
+		if len(entries) >= hops {
+			if ip := net.ParseIP(strings.TrimSpace(entries[len(entries)-hops])); ip != nil {
+				return ip.String()
+			}
+		}
+
+		if ip := net.ParseIP(strings.TrimSpace(hv.request.Header.Get("X-Real-IP"))); ip != nil {
+			return ip.String()
+		}
+	}
+
+	host, _, err := net.SplitHostPort(hv.request.RemoteAddr)
+	if err != nil {
+		return hv.request.RemoteAddr
+	}
+
+	return host
+}
+
+// UserAgent returns the User-Agent header of the request.
+func (hv *HTTPValidator) UserAgent() string {
+	return hv.request.UserAgent()
+}
@@ -2,12 +2,20 @@ package form_validator
 
 import (
 	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
 	"io"
 	"mime/multipart"
+	"net"
+	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestNew(t *testing.T) {
@@ -150,6 +158,70 @@ func TestValidator_Int(t *testing.T) {
 	}
 }
 
+func TestValidator_IntOK(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   int64
+		wantOK bool
+	}{
+		{"0", 0, true},
+		{"42", 42, true},
+		{"-7", -7, true},
+		{"", 0, false},
+		{"abc", 0, false},
+	}
+
+	for _, tt := range tests {
+		v := New()
+		v.SetValue("count", tt.value)
+		got, ok := v.IntOK("count")
+
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("IntOK(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+		if _, hasErr := v.Errors["count"]; hasErr == tt.wantOK {
+			t.Errorf("IntOK(%q) error present = %v, want %v", tt.value, hasErr, !tt.wantOK)
+		}
+	}
+}
+
+func TestValidator_IntGrouped(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{"1,234,567", 1234567, false},
+		{"999", 999, false},
+		{"1234567", 1234567, false},
+		{"-12,345", -12345, false},
+		{" 1,000 ", 1000, false},
+		{"12,34", 0, true},
+		{"1,2345", 0, true},
+		{",123", 0, true},
+		{"1,,234", 0, true},
+		{"1,234,", 0, true},
+		{"0,123", 0, true},
+		{"-0,001", 0, true},
+		{"012,345", 0, true},
+		{"9,223,372,036,854,775,808", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		v := New()
+		v.SetValue("amount", tt.value)
+		got := v.IntGrouped("amount")
+
+		if got != tt.want {
+			t.Errorf("IntGrouped(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+		if _, ok := v.Errors["amount"]; ok != tt.wantErr {
+			t.Errorf("IntGrouped(%q) error present = %v, want %v", tt.value, ok, tt.wantErr)
+		}
+	}
+}
+
 func TestValidator_Image(t *testing.T) {
 	// Create a minimal valid JPEG file content.
 	jpegContent := []byte{
@@ -275,6 +347,29 @@ func TestHTTPValidator(t *testing.T) {
 	}
 }
 
+func TestHTTPValidator_MultipleFiles(t *testing.T) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for _, filename := range []string{"a.jpg", "b.jpg"} {
+		part, _ := writer.CreateFormFile("photos", filename)
+		_, _ = io.Copy(part, strings.NewReader("fake-image-content"))
+	}
+	writer.Close()
+
+	req := httptest.NewRequest("POST", "/", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	v := NewHTTP(req)
+
+	files := v.GetFiles("photos")
+	if len(files) != 2 {
+		t.Fatalf("Expected 2 files, got %d", len(files))
+	}
+	if v.GetFile("photos") != files[0] {
+		t.Error("Expected GetFile to return the first file")
+	}
+}
+
 // Helper function to create a test file.
 func createTestFile(t *testing.T, name, content string) *os.File {
 	t.Helper()
@@ -295,6 +390,30 @@ func createTestFile(t *testing.T, name, content string) *os.File {
 	return tmpfile
 }
 
+// Helper function to create an uploaded file header.
+func createFileHeader(t *testing.T, field, filename string, content []byte) *multipart.FileHeader {
+	t.Helper()
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(field, filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := part.Write(content); err != nil {
+		t.Fatal(err)
+	}
+	writer.Close()
+
+	req := httptest.NewRequest("POST", "/", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if err := req.ParseMultipartForm(32 << 20); err != nil {
+		t.Fatal(err)
+	}
+
+	return req.MultipartForm.File[field][0]
+}
+
 func TestCustomValidations(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -330,6 +449,36 @@ func TestCustomValidations(t *testing.T) {
 			value:    "grape",
 			wantErr:  true,
 		},
+		{
+			name:     "in typed slice passes",
+			validate: InSlice([]int{1, 2, 3}, strconv.Itoa, "Invalid choice"),
+			value:    "2",
+			wantErr:  false,
+		},
+		{
+			name:     "in typed slice fails",
+			validate: InSlice([]int{1, 2, 3}, strconv.Itoa, "Invalid choice"),
+			value:    "4",
+			wantErr:  true,
+		},
+		{
+			name: "decodable passes",
+			validate: Decodable(func(s string) error {
+				_, err := base64.StdEncoding.DecodeString(s)
+				return err
+			}, "Invalid token"),
+			value:   "dG9rZW4=",
+			wantErr: false,
+		},
+		{
+			name: "decodable fails",
+			validate: Decodable(func(s string) error {
+				_, err := base64.StdEncoding.DecodeString(s)
+				return err
+			}, "Invalid token"),
+			value:   "not base64!",
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -351,3 +500,2591 @@ func TestCustomValidations(t *testing.T) {
 		})
 	}
 }
+
+func TestLanguageTag(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"en", true},
+		{"en-US", true},
+		{"pt-BR", true},
+		{"zh-Hant", true},
+		{"zh-Hant-TW", true},
+		{"es-419", true},
+		{"de-CH-1996", true},
+		{"en-US-x-twain", true},
+		{"english", false},
+		{"en_US", false},
+		{"en-", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got, _ := LanguageTag("lang", tt.value); got != tt.want {
+			t.Errorf("LanguageTag(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestIntegerProperties(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate ValidationFunc
+		value    string
+		want     bool
+	}{
+		{"even passes", Even, "4", true},
+		{"even negative passes", Even, "-2", true},
+		{"even zero passes", Even, "0", true},
+		{"even fails on odd", Even, "3", false},
+		{"odd passes", Odd, "7", true},
+		{"odd negative passes", Odd, "-3", true},
+		{"odd fails on even", Odd, "10", false},
+		{"even fails on non-integer", Even, "abc", false},
+		{"odd fails on empty", Odd, "", false},
+		{"power of two one", PowerOfTwo, "1", true},
+		{"power of two", PowerOfTwo, "4096", true},
+		{"power of two fails", PowerOfTwo, "12", false},
+		{"power of two zero", PowerOfTwo, "0", false},
+		{"power of two negative", PowerOfTwo, "-8", false},
+		{"power of two non-integer", PowerOfTwo, "8.0", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := tt.validate("seat", tt.value); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidator_SetValues(t *testing.T) {
+	v := New()
+	v.SetValues("flags", []string{"beta", "analytics"})
+
+	if got := v.GetValue("flags"); got != "beta" {
+		t.Errorf("GetValue() = %v, want %v", got, "beta")
+	}
+
+	if got := v.GetValues("flags"); len(got) != 2 || got[1] != "analytics" {
+		t.Errorf("GetValues() = %v, want %v", got, []string{"beta", "analytics"})
+	}
+}
+
+func TestValidator_RequiresTogether(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		wantErr bool
+	}{
+		{"both selected", []string{"beta", "analytics"}, false},
+		{"neither selected", []string{"dark_mode"}, false},
+		{"only dependency selected", []string{"analytics"}, false},
+		{"dependency missing", []string{"beta", "dark_mode"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New()
+			v.SetValues("flags", tt.values)
+			v.RequiresTogether("flags", "beta", "analytics")
+
+			if _, ok := v.Errors["flags"]; ok != tt.wantErr {
+				t.Errorf("error present = %v, want %v", ok, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_RequiredIf(t *testing.T) {
+	tests := []struct {
+		name    string
+		country string
+		state   string
+		wantErr bool
+	}{
+		{"condition met with value", "US", "CA", false},
+		{"condition met without value", "US", "", true},
+		{"condition met with blank value", "US", "  ", true},
+		{"condition unmet without value", "FR", "", false},
+		{"condition unmet with value", "FR", "IDF", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New()
+			v.SetValue("country", tt.country)
+			v.SetValue("state", tt.state)
+			v.RequiredIf("state", "country", "US")
+
+			if _, ok := v.Errors["state"]; ok != tt.wantErr {
+				t.Errorf("error present = %v, want %v", ok, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_RequiredUnless(t *testing.T) {
+	tests := []struct {
+		name    string
+		payment string
+		iban    string
+		wantErr bool
+	}{
+		{"condition met without value", "card", "", false},
+		{"condition unmet with value", "transfer", "FR7630006000011234567890189", false},
+		{"condition unmet without value", "transfer", "", true},
+		{"other field missing", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New()
+			v.SetValue("payment", tt.payment)
+			v.SetValue("iban", tt.iban)
+			v.RequiredUnless("iban", "payment", "card")
+
+			if _, ok := v.Errors["iban"]; ok != tt.wantErr {
+				t.Errorf("error present = %v, want %v", ok, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_EqualField(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		confirm  string
+		strict   bool
+		wantErr  bool
+	}{
+		{"match", "s3cret!", "s3cret!", false, false},
+		{"mismatch", "s3cret!", "s3cret?", false, true},
+		{"case mismatch", "Secret", "secret", false, true},
+		{"empty confirmation skipped", "s3cret!", "", false, false},
+		{"empty password skipped", "", "s3cret!", false, false},
+		{"strict empty confirmation", "s3cret!", "", true, true},
+		{"strict both empty", "", "", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New()
+			v.SetValue("password", tt.password)
+			v.SetValue("password_confirm", tt.confirm)
+			if tt.strict {
+				v.EqualFieldStrict("password", "password_confirm", "Passwords do not match")
+			} else {
+				v.EqualField("password", "password_confirm", "Passwords do not match")
+			}
+
+			if _, ok := v.Errors["password_confirm"]; ok != tt.wantErr {
+				t.Errorf("error present = %v, want %v", ok, tt.wantErr)
+			}
+			if _, ok := v.Errors["password"]; ok {
+				t.Error("Expected no error on the first field")
+			}
+		})
+	}
+}
+
+func TestValidator_Capture(t *testing.T) {
+	pattern := `^v(\d+)\.(\d+)\.(\d+)$`
+
+	v := New()
+	v.SetValue("version", "v1.2.3")
+	got := v.Capture("version", pattern)
+	if len(got) != 4 || got[1] != "1" || got[2] != "2" || got[3] != "3" {
+		t.Errorf("Capture() = %v, want %v", got, []string{"v1.2.3", "1", "2", "3"})
+	}
+	if !v.Valid() {
+		t.Errorf("Unexpected errors: %v", v.Errors)
+	}
+
+	v = New()
+	v.SetValue("version", "1.2")
+	got = v.Capture("version", pattern)
+	if got == nil || len(got) != 0 {
+		t.Errorf("Capture() = %v, want empty slice", got)
+	}
+	if _, ok := v.Errors["version"]; !ok {
+		t.Error("Expected error for field version")
+	}
+
+	v = New()
+	v.SetValue("version", "1.2")
+	got = v.Capture("version", `(\d+`)
+	if got == nil || len(got) != 0 {
+		t.Errorf("Capture() = %v, want empty slice", got)
+	}
+	if _, ok := v.Errors["version"]; !ok {
+		t.Error("Expected error for an invalid pattern")
+	}
+}
+
+func TestCompilePatternCacheBounded(t *testing.T) {
+	for i := 0; i < maxCompiledPatterns*2; i++ {
+		if _, err := compilePattern("^tenant-" + strconv.Itoa(i) + "$"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	compiledPatterns.Lock()
+	size := len(compiledPatterns.regexes)
+	compiledPatterns.Unlock()
+	if size > maxCompiledPatterns {
+		t.Errorf("cache holds %d patterns, want at most %d", size, maxCompiledPatterns)
+	}
+}
+
+func TestQueryString(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"a=1&b=2", true},
+		{"name=John%20Doe&tags=a&tags=b", true},
+		{"flag", true},
+		{"", true},
+		{"a=%zz", false},
+		{"a=1;b=2", false},
+		{"a%=1", false},
+	}
+
+	for _, tt := range tests {
+		if got, _ := QueryString("query", tt.value); got != tt.want {
+			t.Errorf("QueryString(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestNotPwned(t *testing.T) {
+	validate := NotPwned(map[string]struct{}{
+		"password": {},
+		"123456":   {},
+	})
+
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"password", false},
+		{"PassWord", false},
+		{"123456", false},
+		{"correct horse battery staple", true},
+	}
+
+	for _, tt := range tests {
+		if got, _ := validate("password", tt.value); got != tt.want {
+			t.Errorf("NotPwned(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyForCurrency(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency string
+		amount   string
+		wantErr  bool
+	}{
+		{"usd cents", "USD", "9.99", false},
+		{"usd whole", "USD", "10", false},
+		{"usd too precise", "USD", "9.999", true},
+		{"jpy whole", "JPY", "1500", false},
+		{"jpy decimals", "JPY", "1500.5", true},
+		{"bhd three decimals", "bhd", "1.250", false},
+		{"bhd too precise", "BHD", "1.2505", true},
+		{"unknown currency", "XXX", "1", true},
+		{"not a number", "USD", "ten", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New()
+			v.SetValue("currency", tt.currency)
+			v.SetValue("amount", tt.amount)
+			v.String("amount", Required, v.WithContext(MoneyForCurrency("currency")))
+
+			if _, ok := v.Errors["amount"]; ok != tt.wantErr {
+				t.Errorf("error present = %v, want %v (errors: %v)", ok, tt.wantErr, v.Errors)
+			}
+		})
+	}
+}
+
+func TestDataURI(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate ValidationFunc
+		value    string
+		want     bool
+	}{
+		{"base64 png", DataURI, "data:image/png;base64,iVBORw0KGgo=", true},
+		{"plain text", DataURI, "data:,Hello%2C%20World", true},
+		{"charset parameter", DataURI, "data:text/plain;charset=utf-8,hi", true},
+		{"missing scheme", DataURI, "image/png;base64,iVBORw0KGgo=", false},
+		{"missing comma", DataURI, "data:image/png;base64", false},
+		{"invalid base64", DataURI, "data:image/png;base64,!!!", false},
+		{"invalid media type", DataURI, "data:image/;base64,iVBORw0KGgo=", false},
+		{"allowed type", DataURITypes("image/png", "image/jpeg"), "data:image/png;base64,iVBORw0KGgo=", true},
+		{"disallowed type", DataURITypes("image/png"), "data:text/html,<b>hi</b>", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := tt.validate("content", tt.value); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWordCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate ValidationFunc
+		value    string
+		want     bool
+	}{
+		{"max words within limit", MaxWords(3), "one two three", true},
+		{"max words over limit", MaxWords(3), "one two three four", false},
+		{"repeated spaces do not count", MaxWords(2), "one    two", true},
+		{"surrounding whitespace ignored", MaxWords(2), "  one\ttwo\n ", true},
+		{"min words met", MinWords(2), "hello world", true},
+		{"min words not met", MinWords(2), "  hello  ", false},
+		{"min words empty", MinWords(1), "   ", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := tt.validate("bio", tt.value); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithinPercent(t *testing.T) {
+	tests := []struct {
+		name      string
+		reference string
+		value     string
+		wantErr   bool
+	}{
+		{"exact match", "100", "100", false},
+		{"within tolerance", "100", "100.9", false},
+		{"at tolerance", "200", "198", false},
+		{"outside tolerance", "100", "101.5", true},
+		{"negative reference", "-100", "-100.5", false},
+		{"zero reference matches", "0", "0", false},
+		{"zero reference differs", "0", "0.01", true},
+		{"invalid value", "100", "abc", true},
+		{"invalid reference", "", "100", true},
+		{"NaN value", "100", "NaN", true},
+		{"infinite value", "100", "Inf", true},
+		{"NaN reference", "NaN", "100", true},
+		{"infinite reference", "-Inf", "100", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New()
+			v.SetValue("computed_total", tt.reference)
+			v.SetValue("reported_total", tt.value)
+			v.String("reported_total", v.WithContext(WithinPercent("computed_total", 1)))
+
+			if _, ok := v.Errors["reported_total"]; ok != tt.wantErr {
+				t.Errorf("error present = %v, want %v (errors: %v)", ok, tt.wantErr, v.Errors)
+			}
+		})
+	}
+}
+
+func TestNationalPhone(t *testing.T) {
+	tests := []struct {
+		country string
+		value   string
+		want    bool
+	}{
+		{"US", "(415) 555-2671", true},
+		{"us", "415.555.2671", true},
+		{"US", "4155552671", true},
+		{"US", "155-555-2671", false},
+		{"US", "555-2671", false},
+		{"US", "+14155552671", false},
+		{"FR", "06 12 34 56 78", true},
+		{"FR", "6 12 34 56 78", false},
+		{"GB", "020 7946 0958", true},
+	}
+
+	for _, tt := range tests {
+		if got, _ := NationalPhone(tt.country)("phone", tt.value); got != tt.want {
+			t.Errorf("NationalPhone(%q)(%q) = %v, want %v", tt.country, tt.value, got, tt.want)
+		}
+	}
+
+	assertPanics(t, "unsupported country", func() {
+		NationalPhone("ZZ")
+	})
+}
+
+func TestPhone(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"+14155552671", true},
+		{"+1 (415) 555-2671", true},
+		{"+44 20 7946 0958", true},
+		{"+1234567", true},
+		{"+123456", false},
+		{"+1234567890123456", false},
+		{"+04155552671", false},
+		{"14155552671", false},
+		{"555-CALL", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got, _ := Phone("phone", tt.value); got != tt.want {
+			t.Errorf("Phone(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestPhoneRegion(t *testing.T) {
+	tests := []struct {
+		region string
+		value  string
+		want   bool
+	}{
+		{"US", "+14155552671", true},
+		{"US", "(415) 555-2671", true},
+		{"us", "+1 415 555 2671", true},
+		{"US", "+33612345678", false},
+		{"FR", "+33 6 12 34 56 78", true},
+		{"FR", "06 12 34 56 78", true},
+		{"FR", "+14155552671", false},
+		{"US", "555-CALL", false},
+	}
+
+	for _, tt := range tests {
+		if got, _ := PhoneRegion(tt.region)("phone", tt.value); got != tt.want {
+			t.Errorf("PhoneRegion(%q)(%q) = %v, want %v", tt.region, tt.value, got, tt.want)
+		}
+	}
+
+	assertPanics(t, "unsupported region", func() {
+		PhoneRegion("ZZ")
+	})
+}
+
+func TestHostPort(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"localhost:8080", true},
+		{"db.example.com:5432", true},
+		{"127.0.0.1:80", true},
+		{"[::1]:443", true},
+		{"example.com:65535", true},
+		{"example.com", false},
+		{"example.com:", false},
+		{"example.com:0", false},
+		{"example.com:65536", false},
+		{"example.com:http", false},
+		{"-bad-.com:80", false},
+		{":80", false},
+	}
+
+	for _, tt := range tests {
+		if got, _ := HostPort("address", tt.value); got != tt.want {
+			t.Errorf("HostPort(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestPort(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate ValidationFunc
+		value    string
+		want     bool
+	}{
+		{"lowest port", Port, "1", true},
+		{"highest port", Port, "65535", true},
+		{"zero rejected", Port, "0", false},
+		{"too high", Port, "65536", false},
+		{"negative", Port, "-1", false},
+		{"not a number", Port, "http", false},
+		{"empty", Port, "", false},
+		{"zero allowed", PortAllowZero, "0", true},
+		{"zero allowed too high", PortAllowZero, "70000", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := tt.validate("port", tt.value); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxDateSpan(t *testing.T) {
+	tests := []struct {
+		name    string
+		start   string
+		end     string
+		wantErr bool
+	}{
+		{"within span", "2024-01-01", "2024-02-15", false},
+		{"exactly max span", "2024-01-01", "2024-03-31", false},
+		{"same day", "2024-01-01", "2024-01-01", false},
+		{"exceeds span", "2024-01-01", "2024-04-01", true},
+		{"inverted range", "2024-02-01", "2024-01-01", true},
+		{"invalid start", "2024-13-01", "2024-01-01", true},
+		{"missing end", "2024-01-01", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New()
+			v.SetValue("start", tt.start)
+			v.SetValue("end", tt.end)
+			v.String("end", v.WithContext(MaxDateSpan("start", "end", "2006-01-02", 90)))
+
+			if _, ok := v.Errors["end"]; ok != tt.wantErr {
+				t.Errorf("error present = %v, want %v (errors: %v)", ok, tt.wantErr, v.Errors)
+			}
+		})
+	}
+}
+
+func TestBalancedBrackets(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"", true},
+		{"a + b", true},
+		{"(a + b) * [c - {d / e}]", true},
+		{"((()))", true},
+		{"(a + b", false},
+		{"a + b)", false},
+		{"(a + b]", false},
+		{"([)]", false},
+		{")(", false},
+	}
+
+	for _, tt := range tests {
+		if got, _ := BalancedBrackets("formula", tt.value); got != tt.want {
+			t.Errorf("BalancedBrackets(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestValidator_SetDefaultMessage(t *testing.T) {
+	v := New()
+	v.Check(false, "terms", "")
+	if v.Errors["terms"] != "Invalid value" {
+		t.Errorf("Expected built-in default message, got %q", v.Errors["terms"])
+	}
+
+	v = New()
+	v.SetDefaultMessage("Please check this field")
+	v.Check(false, "terms", "")
+	v.Check(false, "age", "Too young")
+	v.SetValue("name", "x")
+	v.String("name", Custom(func(s string) bool { return false }, ""))
+
+	if v.Errors["terms"] != "Please check this field" {
+		t.Errorf("Expected default message for Check, got %q", v.Errors["terms"])
+	}
+	if v.Errors["age"] != "Too young" {
+		t.Errorf("Expected explicit message to be kept, got %q", v.Errors["age"])
+	}
+	if v.Errors["name"] != "Please check this field" {
+		t.Errorf("Expected default message for String, got %q", v.Errors["name"])
+	}
+}
+
+func TestMatchesAny(t *testing.T) {
+	validate := MatchesAny([]string{`^[0-9]{8}$`, `^[A-Z]{2}-[0-9]{4}$`}, "Please enter a valid ID")
+
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"12345678", true},
+		{"AB-1234", true},
+		{"1234", false},
+		{"ab-1234", false},
+	}
+
+	for _, tt := range tests {
+		got, message := validate("id", tt.value)
+		if got != tt.want {
+			t.Errorf("MatchesAny(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+		if !got && message != "Please enter a valid ID" {
+			t.Errorf("Unexpected message %q", message)
+		}
+	}
+}
+
+func TestFiniteNumber(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"0", true},
+		{"-12.5", true},
+		{"1e10", true},
+		{"Inf", false},
+		{"+Inf", false},
+		{"-infinity", false},
+		{"NaN", false},
+		{"1e400", false},
+		{"abc", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got, _ := FiniteNumber("amount", tt.value); got != tt.want {
+			t.Errorf("FiniteNumber(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestNotContainsField(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		password string
+		wantErr  bool
+	}{
+		{"unrelated password", "john", "s3cure-Passw0rd", false},
+		{"equal to username", "john", "john", true},
+		{"contains username", "john", "John1234!", true},
+		{"empty username", "", "anything", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New()
+			v.SetValue("username", tt.username)
+			v.SetValue("password", tt.password)
+			v.String("password", v.WithContext(NotContainsField("username")))
+
+			if _, ok := v.Errors["password"]; ok != tt.wantErr {
+				t.Errorf("error present = %v, want %v (errors: %v)", ok, tt.wantErr, v.Errors)
+			}
+		})
+	}
+}
+
+func TestValidator_Enum(t *testing.T) {
+	allowed := []string{"Small", "Medium", "Large"}
+
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"Medium", "Medium", false},
+		{"  medium ", "Medium", false},
+		{"LARGE", "Large", false},
+		{"huge", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		v := New()
+		v.SetValue("size", tt.value)
+		got := v.Enum("size", allowed)
+
+		if got != tt.want {
+			t.Errorf("Enum(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+		if _, ok := v.Errors["size"]; ok != tt.wantErr {
+			t.Errorf("Enum(%q) error present = %v, want %v", tt.value, ok, tt.wantErr)
+		}
+	}
+}
+
+func TestObjectID(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"507f1f77bcf86cd799439011", true},
+		{"507F1F77BCF86CD799439011", true},
+		{"507f1f77bcf86cd79943901", false},
+		{"507f1f77bcf86cd7994390111", false},
+		{"507f1f77bcf86cd79943901g", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got, _ := ObjectID("id", tt.value); got != tt.want {
+			t.Errorf("ObjectID(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestMatchesHash(t *testing.T) {
+	compare := func(hash, plain string) bool {
+		return hash == "hashed:"+plain
+	}
+	validate := MatchesHash("hashed:secret", compare)
+
+	if ok, _ := validate("current_password", "secret"); !ok {
+		t.Error("Expected matching password to pass")
+	}
+	if ok, message := validate("current_password", "wrong"); ok || message != "Current password is incorrect" {
+		t.Errorf("Expected mismatch to fail, got %v %q", ok, message)
+	}
+}
+
+func TestUnixTimestamp(t *testing.T) {
+	min := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	max := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	validate := UnixTimestamp(min, max)
+
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"1704067200", true},
+		{"1735689600", true},
+		{"1719792000", true},
+		{"1704067199", false},
+		{"1735689601", false},
+		{"1719792000.5", false},
+		{"yesterday", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got, _ := validate("ts", tt.value); got != tt.want {
+			t.Errorf("UnixTimestamp(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestRecentUnixTimestamp(t *testing.T) {
+	validate := RecentUnixTimestamp(5 * time.Minute)
+	now := time.Now().Unix()
+
+	tests := []struct {
+		name  string
+		value string
+		want  bool
+	}{
+		{"now", strconv.FormatInt(now, 10), true},
+		{"slightly in the past", strconv.FormatInt(now-60, 10), true},
+		{"slightly in the future", strconv.FormatInt(now+60, 10), true},
+		{"too old", strconv.FormatInt(now-600, 10), false},
+		{"too far in the future", strconv.FormatInt(now+600, 10), false},
+		{"milliseconds", strconv.FormatInt(now*1000, 10), false},
+		{"not a number", "now", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := validate("timestamp", tt.value); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCategoryConfig(t *testing.T) {
+	config := CategoryConfig(1*MB, CategoryImage, CategoryVideo)
+	if len(config.AllowedTypes) != 2 || config.AllowedTypes[0] != "image/" || config.AllowedTypes[1] != "video/" {
+		t.Errorf("Unexpected allowed types: %v", config.AllowedTypes)
+	}
+
+	pngContent := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	tests := []struct {
+		name     string
+		filename string
+		content  []byte
+		config   FileValidationConfig
+		wantErr  bool
+	}{
+		{"image in image category", "a.png", pngContent, CategoryConfig(1*MB, CategoryImage), false},
+		{"pdf in document category", "a.pdf", []byte("%PDF-1.4\n"), CategoryConfig(1*MB, CategoryDocument), false},
+		{"text in image category", "a.png", []byte("hello"), CategoryConfig(1*MB, CategoryImage), true},
+		{"image in document category", "a.png", pngContent, CategoryConfig(1*MB, CategoryDocument), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New()
+			v.SetFile("upload", createFileHeader(t, "upload", tt.filename, tt.content))
+			v.Image("upload", tt.config)
+
+			if _, ok := v.Errors["upload"]; ok != tt.wantErr {
+				t.Errorf("error present = %v, want %v (errors: %v)", ok, tt.wantErr, v.Errors)
+			}
+		})
+	}
+}
+
+func TestValidator_File(t *testing.T) {
+	pdfContent := []byte("%PDF-1.4\n")
+	csvContent := []byte("name,email\njohn,john@example.com\n")
+	docxContent := createZip(t, "[Content_Types].xml", "word/document.xml")
+	xlsxContent := createZip(t, "[Content_Types].xml", "xl/workbook.xml")
+
+	tests := []struct {
+		name     string
+		filename string
+		content  []byte
+		config   FileValidationConfig
+		wantErr  bool
+	}{
+		{"pdf", "report.pdf", pdfContent, DocumentConfig(1 * MB), false},
+		{"csv", "contacts.csv", csvContent, DocumentConfig(1 * MB), false},
+		{"docx", "letter.docx", docxContent, DocumentConfig(1 * MB), false},
+		{"xlsx", "sheet.xlsx", xlsxContent, DocumentConfig(1 * MB), false},
+		{"renamed zip", "letter.docx", createZip(t, "word/document.xml"), DocumentConfig(1 * MB), true},
+		{"xlsx named docx", "letter.docx", xlsxContent, DocumentConfig(1 * MB), true},
+		{"docx named pdf", "report.pdf", docxContent, DocumentConfig(1*MB, "pdf", "docx"), true},
+		{"format not allowed", "contacts.csv", csvContent, DocumentConfig(1*MB, "pdf"), true},
+		{"content not matching extension", "report.pdf", csvContent, DocumentConfig(1*MB, "pdf"), true},
+		{"too large", "report.pdf", pdfContent, DocumentConfig(4), true},
+		{"image", "photo.png", []byte("\x89PNG\r\n\x1a\n"), DocumentConfig(1 * MB), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New()
+			v.SetFile("upload", createFileHeader(t, "upload", tt.filename, tt.content))
+			file := v.File("upload", tt.config)
+
+			if _, ok := v.Errors["upload"]; ok != tt.wantErr {
+				t.Errorf("error present = %v, want %v (errors: %v)", ok, tt.wantErr, v.Errors)
+			}
+			if (file == nil) != tt.wantErr {
+				t.Errorf("File() = %v, want nil: %v", file, tt.wantErr)
+			}
+		})
+	}
+
+	assertPanics(t, "unknown document format", func() {
+		DocumentConfig(1*MB, "doc")
+	})
+}
+
+func TestValidator_FileShortContent(t *testing.T) {
+	// A PNG signature and IHDR chunk, far shorter than the 512 sniffed bytes.
+	pngContent := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x01\x00\x00\x00\x01\x08\x02\x00\x00\x00")
+
+	v := New()
+	v.SetFile("avatar", createFileHeader(t, "avatar", "avatar.png", pngContent))
+	if file := v.Image("avatar", ImageConfig(1*MB, "png")); file == nil {
+		t.Errorf("Expected short PNG to be detected, got %v", v.Errors)
+	}
+
+	v = New()
+	v.SetFile("notes", createFileHeader(t, "notes", "notes.csv", []byte("a,b\n")))
+	if file := v.File("notes", DocumentConfig(1*MB, "csv")); file == nil {
+		t.Errorf("Expected short CSV to be detected as text, got %v", v.Errors)
+	}
+}
+
+func TestValidator_WriteJSON(t *testing.T) {
+	tests := []struct {
+		name       string
+		email      string
+		status     int
+		wantStatus int
+		wantBody   string
+	}{
+		{"valid", "john@example.com", http.StatusUnprocessableEntity, http.StatusOK, `{"valid":true,"errors":{}}`},
+		{"invalid", "john", http.StatusBadRequest, http.StatusBadRequest, `{"valid":false,"errors":{"email":"Please enter a valid email address"}}`},
+		{"zero status", "john", 0, http.StatusUnprocessableEntity, `{"valid":false,"errors":{"email":"Please enter a valid email address"}}`},
+		{"out of range status", "john", 1000, http.StatusUnprocessableEntity, `{"valid":false,"errors":{"email":"Please enter a valid email address"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New()
+			v.SetValue("email", tt.email)
+			v.String("email", Email)
+
+			recorder := httptest.NewRecorder()
+			if err := v.WriteJSON(recorder, tt.status); err != nil {
+				t.Fatal(err)
+			}
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if got := recorder.Header().Get("Content-Type"); got != "application/json; charset=utf-8" {
+				t.Errorf("Content-Type = %q", got)
+			}
+			if got := strings.TrimSpace(recorder.Body.String()); got != tt.wantBody {
+				t.Errorf("body = %s, want %s", got, tt.wantBody)
+			}
+		})
+	}
+
+	if err := New().WriteJSON(failingWriter{httptest.NewRecorder()}, http.StatusOK); err == nil {
+		t.Error("Expected error when the body cannot be written")
+	}
+}
+
+// failingWriter is a ResponseWriter whose body writes always fail.
+type failingWriter struct {
+	http.ResponseWriter
+}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func TestValidator_Finish(t *testing.T) {
+	var calls int
+	var received map[string]string
+
+	v := New()
+	v.OnInvalid(func(errors map[string]string) {
+		calls++
+		received = errors
+	})
+
+	if !v.Finish() {
+		t.Error("Expected Finish() to return true without errors")
+	}
+	if calls != 0 {
+		t.Errorf("Expected no callback on success, got %d calls", calls)
+	}
+
+	v.Check(false, "email", "Invalid email")
+	if v.Finish() {
+		t.Error("Expected Finish() to return false with errors")
+	}
+	if calls != 1 || received["email"] != "Invalid email" {
+		t.Errorf("Expected one callback with errors, got %d calls and %v", calls, received)
+	}
+}
+
+func TestHashtagAndMention(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate ValidationFunc
+		value    string
+		want     bool
+	}{
+		{"hashtag", Hashtag, "#golang", true},
+		{"hashtag with underscore and digits", Hashtag, "#go_1_23", true},
+		{"hashtag unicode", Hashtag, "#café", true},
+		{"hashtag missing symbol", Hashtag, "golang", false},
+		{"hashtag empty", Hashtag, "#", false},
+		{"hashtag with space", Hashtag, "#go lang", false},
+		{"hashtag with dash", Hashtag, "#go-lang", false},
+		{"hashtag too long", Hashtag, "#" + strings.Repeat("a", 101), false},
+		{"mention", Mention, "@gopher", true},
+		{"mention wrong symbol", Mention, "#gopher", false},
+		{"mention with dot", Mention, "@go.pher", false},
+		{"mention too long", Mention, "@" + strings.Repeat("a", 31), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := tt.validate("tag", tt.value); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMinCharClasses(t *testing.T) {
+	tests := []struct {
+		value string
+		n     int
+		want  bool
+	}{
+		{"password", 2, false},
+		{"Password", 2, true},
+		{"Password1", 3, true},
+		{"Password1", 4, false},
+		{"Passw0rd!", 4, true},
+		{"12345 678", 2, false},
+		{"", 1, false},
+	}
+
+	for _, tt := range tests {
+		if got, _ := MinCharClasses(tt.n)("password", tt.value); got != tt.want {
+			t.Errorf("MinCharClasses(%d)(%q) = %v, want %v", tt.n, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestIntBetweenFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		min     string
+		max     string
+		value   string
+		wantErr string
+	}{
+		{"within range", "1", "10", "5", ""},
+		{"at lower bound", "1", "10", "1", ""},
+		{"at upper bound", "1", "10", "10", ""},
+		{"below range", "1", "10", "0", "This field must be between 1 and 10"},
+		{"above range", "1", "10", "11", "This field must be between 1 and 10"},
+		{"not an integer", "1", "10", "five", "This field must be a valid integer"},
+		{"invalid bound", "", "10", "5", "Cannot validate range: min_qty and max_qty must be valid integers"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New()
+			v.SetValue("min_qty", tt.min)
+			v.SetValue("max_qty", tt.max)
+			v.SetValue("qty", tt.value)
+			v.String("qty", v.WithContext(IntBetweenFields("min_qty", "max_qty")))
+
+			if got := v.Errors["qty"]; got != tt.wantErr {
+				t.Errorf("error = %q, want %q", got, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCaptcha(t *testing.T) {
+	verify := func(token, remoteIP string) (bool, error) {
+		switch token {
+		case "valid":
+			return remoteIP == "192.0.2.1", nil
+		case "error":
+			return false, errors.New("provider unavailable")
+		}
+		return false, nil
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr string
+	}{
+		{"valid token", "valid", ""},
+		{"rejected token", "invalid", "CAPTCHA verification failed"},
+		{"missing token", "", "CAPTCHA verification failed"},
+		{"verifier error", "error", "CAPTCHA could not be verified, please try again"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/", strings.NewReader(url.Values{"captcha": {tt.token}}.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.RemoteAddr = "192.0.2.1:51234"
+
+			v := NewHTTP(req)
+			if got := v.RemoteIP(); got != "192.0.2.1" {
+				t.Fatalf("RemoteIP() = %q, want %q", got, "192.0.2.1")
+			}
+
+			v.String("captcha", v.WithContext(Captcha(verify)))
+			if got := v.Errors["captcha"]; got != tt.wantErr {
+				t.Errorf("error = %q, want %q", got, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHTTPValidator_RemoteIP(t *testing.T) {
+	tests := []struct {
+		name       string
+		trustProxy bool
+		hops       int
+		headers    map[string]string
+		want       string
+	}{
+		{"no headers", false, 0, nil, "192.0.2.1"},
+		{"forwarded ignored by default", false, 0, map[string]string{"X-Forwarded-For": "203.0.113.7"}, "192.0.2.1"},
+		{"forwarded trusted", true, 0, map[string]string{"X-Forwarded-For": "203.0.113.7"}, "203.0.113.7"},
+		{"forged forwarded entry", true, 0, map[string]string{"X-Forwarded-For": "6.6.6.6, 203.0.113.9"}, "203.0.113.9"},
+		{"two trusted hops", true, 2, map[string]string{"X-Forwarded-For": "6.6.6.6, 203.0.113.9, 10.0.0.1"}, "203.0.113.9"},
+		{"fewer entries than hops", true, 2, map[string]string{"X-Forwarded-For": "203.0.113.9"}, "192.0.2.1"},
+		{"real ip trusted", true, 0, map[string]string{"X-Real-IP": "203.0.113.8"}, "203.0.113.8"},
+		{"invalid forwarded falls back", true, 0, map[string]string{"X-Forwarded-For": "garbage", "X-Real-IP": "203.0.113.8"}, "203.0.113.8"},
+		{"trusted without headers", true, 0, nil, "192.0.2.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			req.RemoteAddr = "192.0.2.1:51234"
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+
+			v := NewHTTP(req)
+			v.TrustProxy = tt.trustProxy
+			v.TrustedHops = tt.hops
+			if got := v.RemoteIP(); got != tt.want {
+				t.Errorf("RemoteIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTTPValidator_UserAgent(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("User-Agent", "test-agent/1.0")
+
+	if got := NewHTTP(req).UserAgent(); got != "test-agent/1.0" {
+		t.Errorf("UserAgent() = %q, want %q", got, "test-agent/1.0")
+	}
+}
+
+func TestNewHTTPWithOptions_MaxFields(t *testing.T) {
+	form := url.Values{"a": {"1"}, "b": {"2"}, "c": {"3"}}
+
+	tests := []struct {
+		name      string
+		maxFields int
+		wantErr   bool
+	}{
+		{"no limit", 0, false},
+		{"within limit", 3, false},
+		{"over limit", 2, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/", strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			v := NewHTTPWithOptions(req, HTTPOptions{MaxFields: tt.maxFields})
+			if _, ok := v.Errors[FormErrorKey]; ok != tt.wantErr {
+				t.Errorf("error present = %v, want %v (errors: %v)", ok, tt.wantErr, v.Errors)
+			}
+			if tt.wantErr && v.GetValue("a") != "" {
+				t.Error("Expected no values to be loaded when over the limit")
+			}
+			if tt.wantErr && req.Form != nil {
+				t.Error("Expected the form not to be parsed when over the limit")
+			}
+			if !tt.wantErr && v.GetValue("a") != "1" {
+				t.Error("Expected values to be loaded")
+			}
+		})
+	}
+}
+
+func TestNewHTTPWithOptions_MaxFieldsQuery(t *testing.T) {
+	body := url.Values{"c": {"3"}}
+	req := httptest.NewRequest("POST", "/?a=1&b=2&a=3", strings.NewReader(body.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	v := NewHTTPWithOptions(req, HTTPOptions{MaxFields: 3})
+	if !v.Valid() || v.GetValue("c") != "3" || len(v.GetValues("a")) != 2 {
+		t.Errorf("Expected repeated keys to count once and the body to stay readable, got %v", v.Errors)
+	}
+
+	req = httptest.NewRequest("POST", "/?a=1&b=2", strings.NewReader(body.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	v = NewHTTPWithOptions(req, HTTPOptions{MaxFields: 2})
+	if _, ok := v.Errors[FormErrorKey]; !ok {
+		t.Error("Expected query and body keys to be counted together")
+	}
+}
+
+func TestNewHTTPWithOptions_MaxValues(t *testing.T) {
+	repeated := strings.Repeat("a=1&", 100)
+
+	tests := []struct {
+		name    string
+		body    string
+		options HTTPOptions
+		wantErr bool
+	}{
+		{"repeated key within default", strings.Repeat("a=1&", 30), HTTPOptions{MaxFields: 3}, false},
+		{"repeated key over default", repeated, HTTPOptions{MaxFields: 3}, true},
+		{"repeated key within explicit limit", repeated, HTTPOptions{MaxFields: 3, MaxValues: 100}, false},
+		{"values limit only", repeated, HTTPOptions{MaxValues: 50}, true},
+		{"body too large", "a=" + strings.Repeat("x", maxFormBodySize), HTTPOptions{MaxFields: 3}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			v := NewHTTPWithOptions(req, tt.options)
+			if _, ok := v.Errors[FormErrorKey]; ok != tt.wantErr {
+				t.Errorf("error present = %v, want %v (errors: %v)", ok, tt.wantErr, v.Errors)
+			}
+			if tt.wantErr && req.Form != nil {
+				t.Error("Expected the form not to be parsed when over the limit")
+			}
+			if !tt.wantErr && v.GetValue("a") != "1" {
+				t.Error("Expected values to be loaded")
+			}
+		})
+	}
+}
+
+func TestValidator_AutoTrim(t *testing.T) {
+	v := New()
+	v.SetValue("username", " john ")
+	if got := v.String("username", MinLength(5)); got != " john " || !v.Valid() {
+		t.Errorf("Expected untrimmed value without AutoTrim, got %q (errors: %v)", got, v.Errors)
+	}
+
+	v = New()
+	v.AutoTrim = true
+	v.AutoTrimSkip = []string{"password", "pin"}
+	v.SetValue("username", " john ")
+	v.SetValues("tags", []string{" go ", "web\n"})
+	v.SetValue("password", " s3cret ")
+	v.SetValue("pin", " 1234 ")
+	v.SetValue("passwd", " hunter2 ")
+
+	if got := v.String("username", MinLength(5)); got != "john" {
+		t.Errorf("String() = %q, want %q", got, "john")
+	}
+	if _, ok := v.Errors["username"]; !ok {
+		t.Error("Expected MinLength to run on the trimmed value")
+	}
+	if got := v.GetValues("tags"); got[0] != "go" || got[1] != "web" {
+		t.Errorf("GetValues() = %q, want trimmed values", got)
+	}
+	if got := v.GetValue("password"); got != " s3cret " {
+		t.Errorf("Expected password to be left untouched, got %q", got)
+	}
+	if got := v.GetValue("pin"); got != " 1234 " {
+		t.Errorf("Expected pin to be left untouched, got %q", got)
+	}
+	if got := v.GetValue("passwd"); got != "hunter2" {
+		t.Errorf("Expected unlisted field to be trimmed, got %q", got)
+	}
+
+	v.SetValueTrimmed("password", " s3cret ")
+	if got := v.GetValue("password"); got != "s3cret" {
+		t.Errorf("SetValueTrimmed() stored %q, want %q", got, "s3cret")
+	}
+}
+
+func TestNewHTTPWithOptions_AutoTrim(t *testing.T) {
+	form := url.Values{"email": {"  john@example.com "}, "new_password": {" s3cret "}}
+
+	req := httptest.NewRequest("POST", "/", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	v := NewHTTPWithOptions(req, HTTPOptions{AutoTrim: true, AutoTrimSkip: []string{"new_password"}})
+	if got := v.String("email", Email); got != "john@example.com" || !v.Valid() {
+		t.Errorf("String() = %q, want trimmed email (errors: %v)", got, v.Errors)
+	}
+	if got := v.GetValue("new_password"); got != " s3cret " {
+		t.Errorf("Expected password to be left untouched, got %q", got)
+	}
+	if got := req.Form.Get("email"); got != "  john@example.com " {
+		t.Errorf("Expected request form to be left untouched, got %q", got)
+	}
+}
+
+func TestNoURLs(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"John Doe", true},
+		{"I love example code", true},
+		{"Version 1.2 released", true},
+		{"Ends with a sentence. Next one", true},
+		{"Mr.Smith", true},
+		{"hi.there", true},
+		{"I love it.Really", true},
+		{"Works at the company.Team player", true},
+		{"Visit https://spam.example", false},
+		{"Visit http://1.2.3.4/x", false},
+		{"Go to www.spam", false},
+		{"cheap pills at spam.com", false},
+		{"see EXAMPLE.ORG/offer", false},
+		{"details on bit.ly/abc", false},
+		{"shop at spam.co.uk", false},
+	}
+
+	for _, tt := range tests {
+		if got, _ := NoURLs("bio", tt.value); got != tt.want {
+			t.Errorf("NoURLs(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestValidator_IfValid(t *testing.T) {
+	v := New()
+	ran := false
+	v.IfValid(func() { ran = true })
+	if !ran {
+		t.Error("Expected IfValid to run without errors")
+	}
+
+	v.Check(false, "name", "Required")
+	ran = false
+	v.IfValid(func() { ran = true })
+	if ran {
+		t.Error("Expected IfValid not to run with errors")
+	}
+
+	result := ""
+	v.IfValidElse(func() { result = "ok" }, func() { result = "fail" })
+	if result != "fail" {
+		t.Errorf("IfValidElse ran %q, want %q", result, "fail")
+	}
+
+	result = ""
+	New().IfValidElse(func() { result = "ok" }, func() { result = "fail" })
+	if result != "ok" {
+		t.Errorf("IfValidElse ran %q, want %q", result, "ok")
+	}
+}
+
+func TestRoundsToCents(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"10", true},
+		{"9.9", true},
+		{"9.99", true},
+		{"-0.01", true},
+		{"9.999", false},
+		{"9.990", false},
+		{"1e2", false},
+		{"9,99", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got, _ := RoundsToCents("amount", tt.value); got != tt.want {
+			t.Errorf("RoundsToCents(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestMustConsent(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"true", true},
+		{"on", true},
+		{" YES ", true},
+		{"1", true},
+		{"", false},
+		{"false", false},
+		{"0", false},
+		{"off", false},
+	}
+
+	for _, tt := range tests {
+		if got, _ := MustConsent("consent", tt.value); got != tt.want {
+			t.Errorf("MustConsent(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestJSONPointer(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"", true},
+		{"/", true},
+		{"/foo/0/bar", true},
+		{"/a~1b/m~0n", true},
+		{"foo/bar", false},
+		{"/foo~", false},
+		{"/foo~2", false},
+	}
+
+	for _, tt := range tests {
+		if got, _ := JSONPointer("pointer", tt.value); got != tt.want {
+			t.Errorf("JSONPointer(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestPublicIP(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"8.8.8.8", true},
+		{"2001:4860:4860::8888", true},
+		{"10.0.0.1", false},
+		{"172.16.5.4", false},
+		{"192.168.1.1", false},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fc00::1", false},
+		{"0.0.0.0", false},
+		{"0.1.2.3", false},
+		{"100.64.0.1", false},
+		{"239.1.2.3", false},
+		{"224.0.0.1", false},
+		{"255.255.255.255", false},
+		{"198.18.0.1", false},
+		{"::ffff:10.0.0.1", false},
+		{"::ffff:8.8.8.8", true},
+		{"64:ff9b::a00:1", false},
+		{"2001:db8::1", false},
+		{"ff02::1", false},
+		{"not-an-ip", false},
+	}
+
+	for _, tt := range tests {
+		if got, _ := PublicIP("target", tt.value); got != tt.want {
+			t.Errorf("PublicIP(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+// fakeResolver resolves hosts from a fixed table, or blocks until the context
+// is done for hosts missing from it.
+type fakeResolver map[string][]string
+
+func (r fakeResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	ips, ok := r[host]
+	if !ok {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	addrs := make([]net.IPAddr, 0, len(ips))
+	for _, ip := range ips {
+		addrs = append(addrs, net.IPAddr{IP: net.ParseIP(ip)})
+	}
+	return addrs, nil
+}
+
+func TestPublicURL(t *testing.T) {
+	resolver := fakeResolver{
+		"example.com":  {"93.184.216.34"},
+		"localhost":    {"127.0.0.1"},
+		"rebind.test":  {"93.184.216.34", "10.0.0.1"},
+		"nowhere.test": {},
+	}
+	publicURL := PublicURLWith(resolver, 50*time.Millisecond)
+
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"https://8.8.8.8/hook", true},
+		{"http://[2001:4860:4860::8888]:8080/hook", true},
+		{"https://example.com/hook", true},
+		{"http://127.0.0.1/hook", false},
+		{"http://169.254.169.254/latest/meta-data", false},
+		{"http://localhost/hook", false},
+		{"http://rebind.test/hook", false},
+		{"http://nowhere.test/hook", false},
+		{"http://slow.test/hook", false},
+		{"ftp://8.8.8.8/file", false},
+		{"/relative/path", false},
+	}
+
+	for _, tt := range tests {
+		if got, _ := publicURL("webhook", tt.value); got != tt.want {
+			t.Errorf("PublicURLWith(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+
+	if got, _ := PublicURL("webhook", "https://8.8.8.8/hook"); !got {
+		t.Error("PublicURL() = false for a public IP")
+	}
+}
+
+func TestDurationBetween(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate ValidationFunc
+		value    string
+		want     bool
+	}{
+		{"within bounds", DurationBetween(time.Minute, 24*time.Hour), "30m", true},
+		{"at lower bound", DurationBetween(time.Minute, 24*time.Hour), "60s", true},
+		{"at upper bound", DurationBetween(time.Minute, 24*time.Hour), "24h", true},
+		{"below bounds", DurationBetween(time.Minute, 24*time.Hour), "30s", false},
+		{"above bounds", DurationBetween(time.Minute, 24*time.Hour), "25h", false},
+		{"negative rejected", DurationBetween(0, time.Hour), "-5m", false},
+		{"negative allowed", DurationBetween(-time.Hour, time.Hour), "-5m", true},
+		{"invalid duration", DurationBetween(0, time.Hour), "5 minutes", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := tt.validate("timeout", tt.value); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBearerToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate ValidationFunc
+		value    string
+		want     bool
+	}{
+		{"bearer token", BearerToken, "Bearer abc123", true},
+		{"bearer jwt", BearerToken, "Bearer eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxIn0.sig-_", true},
+		{"bearer padding", BearerToken, "Bearer dG9rZW4=", true},
+		{"missing prefix", BearerToken, "abc123", false},
+		{"lowercase prefix", BearerToken, "bearer abc123", false},
+		{"empty token", BearerToken, "Bearer ", false},
+		{"token with space", BearerToken, "Bearer abc 123", false},
+		{"header value", HTTPHeaderValue, "Basic dXNlcjpwYXNz\twith tab", true},
+		{"header value with newline", HTTPHeaderValue, "value\r\nX-Injected: 1", false},
+		{"header value with nul", HTTPHeaderValue, "value\x00", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := tt.validate("header", tt.value); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxPercentOf(t *testing.T) {
+	validate := MaxPercentOf(50, 120)
+
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"40", true},
+		{"60", true},
+		{"60.01", false},
+		{"NaN", false},
+		{"abc", false},
+	}
+
+	for _, tt := range tests {
+		if got, _ := validate("bid", tt.value); got != tt.want {
+			t.Errorf("MaxPercentOf(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+
+	if _, message := validate("bid", "100"); message != "Value cannot exceed 120% of 50" {
+		t.Errorf("Unexpected message %q", message)
+	}
+}
+
+func TestSafeRedirectPath(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"/", true},
+		{"/dashboard", true},
+		{"/search?q=a&page=2#results", true},
+		{"//evil.com", false},
+		{"/\\evil.com", false},
+		{"\\\\evil.com", false},
+		{"https://evil.com", false},
+		{"javascript:alert(1)", false},
+		{"dashboard", false},
+		{"/\tevil", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got, _ := SafeRedirectPath("redirect_to", tt.value); got != tt.want {
+			t.Errorf("SafeRedirectPath(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestRoutingNumber(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"011000015", true},
+		{"021000021", true},
+		{"021000022", false},
+		{"02100002", false},
+		{"0210000210", false},
+		{"02100002a", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got, _ := RoutingNumber("routing", tt.value); got != tt.want {
+			t.Errorf("RoutingNumber(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestAcceptLanguageList(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"en", true},
+		{"en-US,en;q=0.9,fr;q=0.8", true},
+		{"fr-CH, fr;q=0.9, en;q=0.8, *;q=0.5", true},
+		{"en;q=1", true},
+		{"en;q=0", true},
+		{"en;q=2", false},
+		{"en;q=1.5", false},
+		{"en;q=0.1234", false},
+		{"en;q=", false},
+		{"en;level=1", false},
+		{"en,,fr", false},
+		{"en_US", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got, _ := AcceptLanguageList("languages", tt.value); got != tt.want {
+			t.Errorf("AcceptLanguageList(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestValidator_Warnings(t *testing.T) {
+	v := New()
+	v.SetValue("password", "short")
+	v.String("password", Required, v.AsWarning(MinLength(12)))
+	v.Warn("username", "This username is similar to an existing one")
+
+	if !v.Valid() {
+		t.Errorf("Expected warnings not to affect Valid(), errors: %v", v.Errors)
+	}
+	if v.Warnings["password"] != "This field must be at least 12 characters long" {
+		t.Errorf("Unexpected password warning %q", v.Warnings["password"])
+	}
+	if _, ok := v.Warnings["username"]; !ok {
+		t.Error("Expected username warning")
+	}
+
+	v.SetValue("password", "")
+	v.String("password", Required, v.AsWarning(MinLength(12)))
+	if v.Valid() {
+		t.Error("Expected hard validation to still fail")
+	}
+}
+
+func TestValidator_SumEquals(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		wantErr bool
+	}{
+		{"sums to target", []string{"50", "30", "20"}, false},
+		{"decimal rounding", []string{"33.3", "33.3", "33.4"}, false},
+		{"below target", []string{"50", "30", "10"}, true},
+		{"above target", []string{"50", "30", "30"}, true},
+		{"invalid number", []string{"50", "abc", "50"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New()
+			fields := []string{"a", "b", "c"}
+			for i, field := range fields {
+				v.SetValue(field, tt.values[i])
+			}
+			v.SumEquals(100, fields...)
+
+			if v.Valid() == tt.wantErr {
+				t.Errorf("Valid() = %v, want %v (errors: %v)", v.Valid(), !tt.wantErr, v.Errors)
+			}
+		})
+	}
+}
+
+func TestPathTemplate(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"/", true},
+		{"/users", true},
+		{"/users/{id}/posts/{postId}", true},
+		{"/files/{name}.{ext}", true},
+		{"users/{id}", false},
+		{"/users/", false},
+		{"/users//posts", false},
+		{"/users/{id", false},
+		{"/users/id}", false},
+		{"/users/{}", false},
+		{"/users/{1id}", false},
+		{"/users/{id}/friends/{id}", false},
+		{"/users/{{id}}", false},
+	}
+
+	for _, tt := range tests {
+		if got, _ := PathTemplate("path", tt.value); got != tt.want {
+			t.Errorf("PathTemplate(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestFitsCharset(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate ValidationFunc
+		value    string
+		want     bool
+	}{
+		{"ascii in latin1", FitsCharset("latin1", 10), "hello", true},
+		{"accent in latin1 single byte", FitsCharset("latin1", 4), "café", true},
+		{"too long in latin1", FitsCharset("latin1", 3), "café", false},
+		{"unrepresentable in latin1", FitsCharset("latin1", 10), "日本", false},
+		{"emoji in latin1", FitsCharset("iso-8859-1", 10), "hi 😀", false},
+		{"euro sign in latin1", FitsCharset("latin1", 10), "€", false},
+		{"euro sign in iso-8859-1", FitsCharset("iso-8859-1", 10), "€", false},
+		{"euro sign in windows-1252", FitsCharset("windows-1252", 10), "€", true},
+		{"japanese in shift_jis", FitsCharset("shift_jis", 4), "日本", true},
+		{"unknown charset", FitsCharset("klingon", 10), "hello", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := tt.validate("name", tt.value); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUniquePrefixOf(t *testing.T) {
+	values := []string{"delete", "deploy", "list", "log", "logs"}
+
+	tests := []struct {
+		value string
+		want  string
+		ok    bool
+	}{
+		{"del", "delete", true},
+		{"delete", "delete", true},
+		{"li", "list", true},
+		{"log", "log", true},
+		{"de", "", false},
+		{"l", "", false},
+		{"x", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		if got, _ := UniquePrefixOf(values)("command", tt.value); got != tt.ok {
+			t.Errorf("UniquePrefixOf(%q) = %v, want %v", tt.value, got, tt.ok)
+		}
+
+		v := New()
+		v.SetValue("command", tt.value)
+		if got := v.UniquePrefix("command", values); got != tt.want {
+			t.Errorf("UniquePrefix(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+		if v.Valid() != tt.ok {
+			t.Errorf("UniquePrefix(%q) valid = %v, want %v", tt.value, v.Valid(), tt.ok)
+		}
+	}
+}
+
+func TestNumberWithUnit(t *testing.T) {
+	validate := NumberWithUnit("K", 2700, 6500)
+
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"4000K", true},
+		{"2700K", true},
+		{"6500K", true},
+		{"4000.5K", true},
+		{"4000 K", true},
+		{"4000", false},
+		{"4000k", false},
+		{"2000K", false},
+		{"7000K", false},
+		{"NaNK", false},
+		{"K", false},
+	}
+
+	for _, tt := range tests {
+		if got, _ := validate("temperature", tt.value); got != tt.want {
+			t.Errorf("NumberWithUnit(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+
+	if _, message := validate("temperature", "1K"); message != "Please enter a value between 2700K and 6500K" {
+		t.Errorf("Unexpected message %q", message)
+	}
+}
+
+func TestIntRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    bool
+		message string
+	}{
+		{"within range", "50", true, ""},
+		{"at minimum", "18", true, ""},
+		{"at maximum", "120", true, ""},
+		{"below minimum", "17", false, "This field must be between 18 and 120"},
+		{"above maximum", "121", false, "This field must be between 18 and 120"},
+		{"very large", "999999", false, "This field must be between 18 and 120"},
+		{"non-numeric", "abc", false, "This field must be a valid integer"},
+		{"empty", "", false, "This field must be a valid integer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, message := IntRange(18, 120)("age", tt.value)
+			if got != tt.want {
+				t.Errorf("IntRange(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+			if message != tt.message {
+				t.Errorf("IntRange(%q) message = %q, want %q", tt.value, message, tt.message)
+			}
+		})
+	}
+}
+
+func TestMinMaxValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate ValidationFunc
+		value    string
+		want     bool
+		message  string
+	}{
+		{"above minimum", MinValue(1), "5", true, ""},
+		{"at minimum", MinValue(1), "1", true, ""},
+		{"below minimum", MinValue(1), "0", false, "This field must be at least 1"},
+		{"negative minimum", MinValue(-10), "-10", true, ""},
+		{"below maximum", MaxValue(100), "99", true, ""},
+		{"at maximum", MaxValue(100), "100", true, ""},
+		{"above maximum", MaxValue(100), "101", false, "This field must not exceed 100"},
+		{"non-numeric minimum", MinValue(1), "one", false, "This field must be a valid integer"},
+		{"non-numeric maximum", MaxValue(100), "", false, "This field must be a valid integer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, message := tt.validate("quantity", tt.value)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if message != tt.message {
+				t.Errorf("message = %q, want %q", message, tt.message)
+			}
+		})
+	}
+}
+
+func TestValidator_Float(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		validations []ValidationFunc
+		want        float64
+		wantErr     bool
+	}{
+		{name: "valid number", value: "4.5", want: 4.5},
+		{name: "integer", value: "3", want: 3},
+		{name: "negative", value: "-0.25", want: -0.25},
+		{name: "comma separator", value: "4,5", wantErr: true},
+		{name: "not a number", value: "abc", wantErr: true},
+		{name: "empty", value: "", wantErr: true},
+		{name: "infinity", value: "Inf", wantErr: true},
+		{name: "negative infinity", value: "-Inf", wantErr: true},
+		{name: "nan", value: "NaN", wantErr: true},
+		{name: "exponent", value: "1.5e2", want: 150},
+		{name: "leading dot", value: ".5", want: 0.5},
+		{name: "hex float", value: "0x1p4", wantErr: true},
+		{name: "underscores", value: "1_000", wantErr: true},
+		{name: "overflow", value: "1e400", wantErr: true},
+		{name: "within range", value: "4.5", validations: []ValidationFunc{FloatRange(0, 5)}, want: 4.5},
+		{name: "out of range", value: "5.5", validations: []ValidationFunc{FloatRange(0, 5)}, want: 5.5, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New()
+			v.SetValue("rating", tt.value)
+			got := v.Float("rating", tt.validations...)
+
+			if _, ok := v.Errors["rating"]; ok != tt.wantErr {
+				t.Errorf("error present = %v, want %v (errors: %v)", ok, tt.wantErr, v.Errors)
+			}
+			if got != tt.want {
+				t.Errorf("Float() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFloatRange(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"0", true},
+		{"2.5", true},
+		{"5", true},
+		{"-0.1", false},
+		{"5.01", false},
+		{"NaN", false},
+		{"Inf", false},
+		{"0x1p2", false},
+		{"0x1", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got, _ := FloatRange(0, 5)("rating", tt.value); got != tt.want {
+			t.Errorf("FloatRange(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestHostnameOrIP(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"localhost", true},
+		{"db-1.internal.example.com", true},
+		{"192.168.0.10", true},
+		{"2001:db8::1", true},
+		{"", false},
+		{"-db.example.com", false},
+		{"db_1.example.com", false},
+		{"db.example.com:5432", false},
+		{"[2001:db8::1]", false},
+		{strings.Repeat("a", 64) + ".com", false},
+	}
+
+	for _, tt := range tests {
+		if got, _ := HostnameOrIP("host", tt.value); got != tt.want {
+			t.Errorf("HostnameOrIP(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestInSetFunc(t *testing.T) {
+	plans := map[string]struct{}{"basic": {}}
+	validate := InSetFunc(func() map[string]struct{} { return plans }, "Unknown plan")
+
+	if ok, _ := validate("plan", "basic"); !ok {
+		t.Error("Expected basic plan to pass")
+	}
+	if ok, message := validate("plan", "pro"); ok || message != "Unknown plan" {
+		t.Errorf("Expected pro plan to fail, got %v %q", ok, message)
+	}
+
+	plans = map[string]struct{}{"basic": {}, "pro": {}}
+	if ok, _ := validate("plan", "pro"); !ok {
+		t.Error("Expected refreshed set to be used")
+	}
+}
+
+func TestValidator_CollectAll(t *testing.T) {
+	validations := []ValidationFunc{
+		MinLength(8),
+		Matches(`[0-9]`, "Must contain a digit"),
+		Matches(`[A-Z]`, "Must contain an uppercase letter"),
+	}
+
+	v := New()
+	v.SetValue("password", "abc")
+	v.String("password", validations...)
+	if got := v.ErrorsFor("password"); len(got) != 1 {
+		t.Errorf("Expected a single error by default, got %v", got)
+	}
+
+	v = New()
+	v.CollectAll = true
+	v.SetValue("password", "abc")
+	v.String("password", validations...)
+
+	want := []string{
+		"This field must be at least 8 characters long",
+		"Must contain a digit",
+		"Must contain an uppercase letter",
+	}
+	got := v.ErrorsFor("password")
+	if len(got) != len(want) {
+		t.Fatalf("ErrorsFor() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ErrorsFor()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+	if _, ok := v.Errors["password"]; !ok {
+		t.Error("Expected Errors map to still be populated")
+	}
+	if v.ErrorsFor("username") != nil {
+		t.Error("Expected no errors for an untouched field")
+	}
+}
+
+func TestValidator_ErrorsForNoDuplicates(t *testing.T) {
+	v := New()
+	v.SetValue("age", "abc")
+	v.Int("age", IntRange(1, 10))
+
+	if got := v.ErrorsFor("age"); len(got) != 1 || got[0] != "This field must be a valid integer" {
+		t.Errorf("ErrorsFor() = %q, want a single parse error", got)
+	}
+
+	v = New()
+	v.SetValue("seats", "abc")
+	if _, err := v.BindDynamic(map[string]string{"seats": "int"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := v.ErrorsFor("seats"); len(got) != 1 {
+		t.Errorf("ErrorsFor() = %q, want a single parse error", got)
+	}
+}
+
+func TestValidator_SortedErrors(t *testing.T) {
+	v := New()
+	for _, field := range []string{"zip", "email", "name", "age", "country"} {
+		v.addError(field, "Invalid "+field)
+	}
+
+	want := []FieldError{
+		{"age", "Invalid age"},
+		{"country", "Invalid country"},
+		{"email", "Invalid email"},
+		{"name", "Invalid name"},
+		{"zip", "Invalid zip"},
+	}
+
+	for i := 0; i < 10; i++ {
+		got := v.SortedErrors()
+		if len(got) != len(want) {
+			t.Fatalf("SortedErrors() = %v, want %v", got, want)
+		}
+		for j := range want {
+			if got[j] != want[j] {
+				t.Fatalf("SortedErrors()[%d] = %v, want %v", j, got[j], want[j])
+			}
+		}
+	}
+
+	if got := New().SortedErrors(); got == nil || len(got) != 0 {
+		t.Errorf("Expected an empty, non-nil slice without errors, got %#v", got)
+	}
+}
+
+func TestGreaterThanValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate ValidationFunc
+		value    string
+		want     bool
+		message  string
+	}{
+		{"greater", GreaterThanValue(3, ""), "4", true, ""},
+		{"equal", GreaterThanValue(3, ""), "3", false, "Must be greater than 3"},
+		{"lower", GreaterThanValue(3, "Version must increase"), "2", false, "Version must increase"},
+		{"not an integer", GreaterThanValue(3, ""), "3.5", false, "This field must be a valid integer"},
+		{"float greater", GreaterThanFloatValue(1.5, ""), "1.51", true, ""},
+		{"float equal", GreaterThanFloatValue(1.5, ""), "1.5", false, "Must be greater than 1.5"},
+		{"float invalid", GreaterThanFloatValue(1.5, ""), "Inf", false, "This field must be a valid number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, message := tt.validate("version", tt.value)
+			if got != tt.want || message != tt.message {
+				t.Errorf("got (%v, %q), want (%v, %q)", got, message, tt.want, tt.message)
+			}
+		})
+	}
+}
+
+func TestUUID(t *testing.T) {
+	const (
+		v4 = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+		v1 = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	)
+
+	tests := []struct {
+		name     string
+		validate ValidationFunc
+		value    string
+		want     bool
+	}{
+		{"v4", UUID, v4, true},
+		{"v1", UUID, v1, true},
+		{"uppercase", UUID, strings.ToUpper(v4), true},
+		{"braces", UUID, "{" + v4 + "}", false},
+		{"urn", UUID, "urn:uuid:" + v4, false},
+		{"no hyphens", UUID, strings.ReplaceAll(v4, "-", ""), false},
+		{"non-hex", UUID, "g47ac10b-58cc-4372-a567-0e02b2c3d479", false},
+		{"empty", UUID, "", false},
+		{"version 4 matches v4", UUIDVersion(4), v4, true},
+		{"version 4 rejects v1", UUIDVersion(4), v1, false},
+		{"version 1 matches v1", UUIDVersion(1), v1, true},
+		{"version 1 rejects v4", UUIDVersion(1), v4, false},
+		{"version rejects malformed", UUIDVersion(4), "not-a-uuid", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, message := tt.validate("id", tt.value)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if !got && message != "Please enter a valid UUID" {
+				t.Errorf("Unexpected message %q", message)
+			}
+		})
+	}
+}
+
+func TestValidator_CSVCount(t *testing.T) {
+	tests := []struct {
+		value   string
+		wantErr bool
+	}{
+		{"go", false},
+		{"go, web , api", false},
+		{"a,b,c,d,e", false},
+		{"a,,b, ,c", false},
+		{"", true},
+		{" , ,", true},
+		{"a,b,c,d,e,f", true},
+	}
+
+	for _, tt := range tests {
+		v := New()
+		v.SetValue("tags", tt.value)
+		v.CSVCount("tags", ",", 1, 5)
+
+		if _, ok := v.Errors["tags"]; ok != tt.wantErr {
+			t.Errorf("CSVCount(%q) error present = %v, want %v", tt.value, ok, tt.wantErr)
+		}
+	}
+}
+
+func TestPercentEncoded(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"hello", true},
+		{"hello%20world", true},
+		{"caf%C3%A9", true},
+		{"a%2Fb", true},
+		{"hello world", false},
+		{"a/b", false},
+		{"caf%c3%a9", false},
+		{"100%", false},
+		{"%zz", false},
+	}
+
+	for _, tt := range tests {
+		if got, _ := PercentEncoded("segment", tt.value); got != tt.want {
+			t.Errorf("PercentEncoded(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestMatchesSafe(t *testing.T) {
+	validate, err := MatchesSafe(`^[a-z]+$`, "Lowercase letters only")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ok, _ := validate("code", "abc"); !ok {
+		t.Error("Expected match to pass")
+	}
+	if ok, message := validate("code", "ABC"); ok || message != "Lowercase letters only" {
+		t.Errorf("Expected mismatch to fail, got %v %q", ok, message)
+	}
+
+	if _, err := MatchesSafe(`^[a-z+$`, "Invalid"); err == nil {
+		t.Error("Expected error for invalid pattern")
+	}
+
+	assertPanics(t, "Matches with invalid pattern", func() {
+		Matches(`^[a-z+$`, "Invalid")
+	})
+}
+
+func TestValidator_ImageForbiddenSignatures(t *testing.T) {
+	pngContent := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	config := ImageConfig(1 * MB)
+	config.ForbiddenSignatures = ExecutableSignatures
+
+	tests := []struct {
+		name    string
+		content []byte
+		wantErr bool
+	}{
+		{"clean image", pngContent, false},
+		{"embedded elf", append(append([]byte{}, pngContent...), "\x7fELF\x02\x01"...), true},
+		{"embedded pe", append(append([]byte{}, pngContent...), "MZ\x90\x00\x03"...), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New()
+			v.SetFile("avatar", createFileHeader(t, "avatar", "avatar.png", tt.content))
+			v.Image("avatar", config)
+
+			if _, ok := v.Errors["avatar"]; ok != tt.wantErr {
+				t.Errorf("error present = %v, want %v (errors: %v)", ok, tt.wantErr, v.Errors)
+			}
+			if tt.wantErr && v.Errors["avatar"] != "File content is not allowed" {
+				t.Errorf("Unexpected message %q", v.Errors["avatar"])
+			}
+		})
+	}
+}
+
+func BenchmarkEmail(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Email("email", "john.doe@example.com")
+	}
+}
+
+func TestDate(t *testing.T) {
+	limit := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		validate ValidationFunc
+		value    string
+		want     bool
+	}{
+		{"iso date", DateISO, "2023-02-28", true},
+		{"leap day", DateISO, "2024-02-29", true},
+		{"impossible date", DateISO, "2023-02-30", false},
+		{"non leap day", DateISO, "2023-02-29", false},
+		{"wrong format", DateISO, "28/02/2023", false},
+		{"empty", DateISO, "", false},
+		{"custom layout", Date("02/01/2006"), "28/02/2023", true},
+		{"before limit", DateBefore("2006-01-02", limit), "2024-05-31", true},
+		{"at limit not before", DateBefore("2006-01-02", limit), "2024-06-01", false},
+		{"after limit not before", DateBefore("2006-01-02", limit), "2024-06-02", false},
+		{"after limit", DateAfter("2006-01-02", limit), "2024-06-02", true},
+		{"at limit not after", DateAfter("2006-01-02", limit), "2024-06-01", false},
+		{"invalid not after", DateAfter("2006-01-02", limit), "soon", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := tt.validate("birthdate", tt.value); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidator_IntListSum(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    []int64
+		wantErr bool
+	}{
+		{"5,3,2", []int64{5, 3, 2}, false},
+		{" 4 , 6 ", []int64{4, 6}, false},
+		{"10", []int64{10}, false},
+		{"5,3,3", []int64{5, 3, 3}, true},
+		{"9223372036854775807,1", []int64{9223372036854775807, 1}, true},
+		{"1,9223372036854775807", []int64{1, 9223372036854775807}, true},
+		{"-100,105", nil, true},
+		{"5,x,2", nil, true},
+		{"5,,2", nil, true},
+		{"", nil, true},
+	}
+
+	for _, tt := range tests {
+		v := New()
+		v.SetValue("points", tt.value)
+		got := v.IntListSum("points", ",", 10)
+
+		if len(got) != len(tt.want) {
+			t.Errorf("IntListSum(%q) = %v, want %v", tt.value, got, tt.want)
+		} else {
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("IntListSum(%q) = %v, want %v", tt.value, got, tt.want)
+					break
+				}
+			}
+		}
+		if _, ok := v.Errors["points"]; ok != tt.wantErr {
+			t.Errorf("IntListSum(%q) error present = %v, want %v", tt.value, ok, tt.wantErr)
+		}
+	}
+}
+
+func TestNotInDates(t *testing.T) {
+	blocked := []time.Time{
+		time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 1, 1, 15, 30, 0, 0, time.UTC),
+	}
+	validate := NotInDates(blocked, "2006-01-02")
+
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"2024-12-24", true},
+		{"2024-12-25", false},
+		{"2025-01-01", false},
+		{"2025-12-25", true},
+		{"25/12/2024", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got, _ := validate("booking", tt.value); got != tt.want {
+			t.Errorf("NotInDates(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+
+	if _, msg := validate("booking", "2024-12-25"); msg != "This date is not available" {
+		t.Errorf("unexpected message %q", msg)
+	}
+}
+
+func TestNFC(t *testing.T) {
+	composed := "Jos\u00e9"
+	decomposed := "Jose\u0301"
+
+	if ok, _ := NFC("username", composed); !ok {
+		t.Error("Expected composed value to pass")
+	}
+	if ok, _ := NFC("username", decomposed); ok {
+		t.Error("Expected decomposed value to fail")
+	}
+
+	v := New()
+	v.SetValue("username", decomposed)
+	v.SetValues("tags", []string{decomposed, "plain"})
+	v.NormalizeNFC("username", "tags", "missing")
+
+	if got := v.GetValue("username"); got != composed {
+		t.Errorf("NormalizeNFC() username = %q, want %q", got, composed)
+	}
+	if got := v.GetValues("tags"); got[0] != composed || got[1] != "plain" {
+		t.Errorf("NormalizeNFC() tags = %q", got)
+	}
+	v.String("username", NFC)
+	if !v.Valid() {
+		t.Errorf("Expected normalized value to be valid, got %v", v.Errors)
+	}
+
+	form := url.Values{"username": {decomposed}}
+	req := httptest.NewRequest("POST", "/", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	hv := NewHTTP(req)
+	hv.NormalizeNFC("username")
+	if got := req.Form.Get("username"); got != decomposed {
+		t.Errorf("Expected the request form to be left untouched, got %q", got)
+	}
+
+	values := []string{decomposed}
+	v.SetValues("tags", values)
+	v.NormalizeNFC("tags")
+	if values[0] != decomposed {
+		t.Errorf("Expected the caller's slice to be left untouched, got %q", values[0])
+	}
+}
+
+func TestSKUMask(t *testing.T) {
+	validate := SKUMask("AAA-999-X")
+
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"ABC-123-Z", true},
+		{"abc-123-4", true},
+		{"AB1-123-Z", false},
+		{"ABC-12C-Z", false},
+		{"ABC_123-Z", false},
+		{"ABC-123-", false},
+		{"ABC-123-ZZ", false},
+		{"ÀBC-123-Z", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got, _ := validate("sku", tt.value); got != tt.want {
+			t.Errorf("SKUMask(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestInPathSet(t *testing.T) {
+	allowed := []string{"electronics/phones/android", "electronics/phones/ios", "books"}
+
+	tests := []struct {
+		name     string
+		validate ValidationFunc
+		value    string
+		want     bool
+	}{
+		{"exact path", InPathSet(allowed, "/"), "electronics/phones/android", true},
+		{"top level path", InPathSet(allowed, "/"), "books", true},
+		{"prefix not allowed", InPathSet(allowed, "/"), "electronics/phones", false},
+		{"unknown path", InPathSet(allowed, "/"), "electronics/laptops", false},
+		{"prefix allowed", InPathPrefixSet(allowed, "/"), "electronics/phones", true},
+		{"root prefix allowed", InPathPrefixSet(allowed, "/"), "electronics", true},
+		{"partial segment", InPathPrefixSet(allowed, "/"), "electronics/pho", false},
+		{"trailing separator", InPathPrefixSet(allowed, "/"), "electronics/", false},
+		{"deeper than allowed", InPathPrefixSet(allowed, "/"), "books/fiction", false},
+		{"other separator", InPathSet([]string{"a > b"}, " > "), "a > b", true},
+		{"empty", InPathPrefixSet(allowed, "/"), "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := tt.validate("category", tt.value); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEthAddress(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		// Checksummed addresses from the EIP-55 specification.
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", true},
+		{"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359", true},
+		{"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB", true},
+		{"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb", true},
+		{"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", true},
+		{"0x5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED", true},
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD", false},
+		{"0x5aAeb6053f3E94C9b9A09f33669435E7Ef1BeAed", false},
+		{"5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", false},
+		{"0X5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", false},
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAe", false},
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeg", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got, _ := EthAddress("wallet", tt.value); got != tt.want {
+			t.Errorf("EthAddress(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestEqualsExpected(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate ValidationFunc
+		value    string
+		want     bool
+		message  string
+	}{
+		{"matches", EqualsExpected("premium", ""), "premium", true, ""},
+		{"differs", EqualsExpected("premium", ""), "basic", false, "Value does not match the earlier selection"},
+		{"case differs", EqualsExpected("premium", ""), "Premium", false, "Value does not match the earlier selection"},
+		{"empty value", EqualsExpected("premium", ""), "", false, "Value does not match the earlier selection"},
+		{"custom message", EqualsExpected("premium", "Plan changed"), "basic", false, "Plan changed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, message := tt.validate("plan", tt.value)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if message != tt.message {
+				t.Errorf("message = %q, want %q", message, tt.message)
+			}
+		})
+	}
+}
+
+func TestCharacterClasses(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate ValidationFunc
+		value    string
+		want     bool
+	}{
+		{"alpha ascii", Alpha, "John", true},
+		{"alpha accented", Alpha, "José", true},
+		{"alpha cyrillic", Alpha, "Иван", true},
+		{"alpha digit", Alpha, "John1", false},
+		{"alpha space", Alpha, "John Doe", false},
+		{"alphanumeric accented", Alphanumeric, "José123", true},
+		{"alphanumeric emoji", Alphanumeric, "john\U0001F600", false},
+		{"alphanumeric symbol", Alphanumeric, "john_doe", false},
+		{"alphanumeric hyphen", Alphanumeric, "john-doe", false},
+		{"numeric", Numeric, "0123", true},
+		{"numeric arabic-indic", Numeric, "١٢٣", true},
+		{"numeric decimal", Numeric, "1.5", false},
+		{"numeric sign", Numeric, "-1", false},
+		{"empty", Alphanumeric, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := tt.validate("username", tt.value); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSlug(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"my-post-123", true},
+		{"post", true},
+		{"2024", true},
+		{"My_Post", false},
+		{"My-Post", false},
+		{"-leading", false},
+		{"trailing-", false},
+		{"double--hyphen", false},
+		{"--x", false},
+		{"with space", false},
+		{"café", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got, _ := Slug("slug", tt.value); got != tt.want {
+			t.Errorf("Slug(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}